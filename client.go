@@ -1,8 +1,12 @@
 package ethtool
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"iter"
 	"math/big"
+	"slices"
 )
 
 //go:generate stringer -type=Duplex,Port -output=string.go
@@ -198,6 +202,16 @@ func (c *Client) FEC(ifi Interface) (*FEC, error) {
 	return c.c.FEC(ifi)
 }
 
+// FECWithStats is like FEC, but also requests FEC statistics which are
+// returned in the FEC.Stats field.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) FECWithStats(ifi Interface) (*FEC, error) {
+	return c.c.FECWithStats(ifi)
+}
+
 // SetFEC sets the forward error correction (FEC) parameters for the Interface
 // in fec.
 //
@@ -219,6 +233,46 @@ type FEC struct {
 	Modes     FECModes
 	Active    FECMode
 	Auto      bool
+
+	// Stats contains FEC statistics. It is only populated when statistics
+	// are requested and supported by the device.
+	Stats *FECStats
+}
+
+// FECStats contains forward error correction (FEC) statistics for an
+// interface.
+type FECStats struct {
+	// Corrected is the number of blocks which contained errors that were
+	// corrected by FEC.
+	Corrected FECCounter
+	// Uncorrectable is the number of blocks which contained errors that FEC
+	// could not correct.
+	Uncorrectable FECCounter
+	// CorrectedBits is the number of bits which were corrected by FEC.
+	CorrectedBits FECCounter
+	// Histogram contains the distribution of the number of symbol errors
+	// corrected per codeword, on kernels and devices which report it.
+	Histogram []FECHistogramBin
+}
+
+// A FECCounter is a FEC statistics counter.
+type FECCounter struct {
+	Total uint64
+	// Lanes contains the value of the counter for each lane of the link, or
+	// nil if the device does not report per-lane values.
+	Lanes []uint64
+}
+
+// A FECHistogramBin is a single bin of a FEC histogram.
+type FECHistogramBin struct {
+	// Low and High are the inclusive range of the number of symbol errors
+	// per codeword which are counted by the bin.
+	Low, High int
+	// Count is the number of codewords which fell in the bin.
+	Count uint64
+	// Lanes contains the count for each lane of the link, or nil if the
+	// device does not report per-lane values.
+	Lanes []uint64
 }
 
 // A FECMode is a FEC mode bit value (single element bitmask) specifying the
@@ -378,5 +432,1683 @@ func (c *Client) SetPrivateFlags(p PrivateFlags) error {
 	return c.c.SetPrivateFlags(p)
 }
 
+// Features contains the netdev features of an Ethernet interface, such as
+// checksum and segmentation offloads. Each set is keyed by feature name, and a
+// feature is a member of a set when its value is true.
+type Features struct {
+	Interface Interface
+	// Hardware contains the features which may be toggled by the user.
+	Hardware map[string]bool
+	// Wanted contains the features which were requested by the user.
+	Wanted map[string]bool
+	// Active contains the features which are currently enabled.
+	Active map[string]bool
+	// NoChange contains the features which can never be changed.
+	NoChange map[string]bool
+}
+
+// AllFeatures fetches Features for each ethtool-supported interface on this
+// system.
+func (c *Client) AllFeatures() ([]*Features, error) {
+	return c.c.AllFeatures()
+}
+
+// Features fetches Features for the specified Interface.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) Features(ifi Interface) (*Features, error) {
+	return c.c.Features(ifi)
+}
+
+// SetFeatures requests that the features named in features are enabled or
+// disabled on the given Interface. Features which are not present in the map
+// are left as-is.
+//
+// The kernel may refuse to apply some changes, for example when a feature
+// depends on another feature which is disabled. The returned map contains the
+// requested changes which were not applied, keyed by feature name with the
+// value that was requested. If every change was applied, the map is empty.
+//
+// Setting Features requires elevated privileges and if the caller does not
+// have permission, an error compatible with errors.Is(err, os.ErrPermission)
+// will be returned.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) SetFeatures(ifi Interface, features map[string]bool) (map[string]bool, error) {
+	return c.c.SetFeatures(ifi, features)
+}
+
+// Rings contains the ring buffer sizes and related parameters for an Ethernet
+// interface.
+type Rings struct {
+	Interface Interface
+
+	// Maximum supported number of entries for each ring.
+	RXMax      int
+	RXMiniMax  int
+	RXJumboMax int
+	TXMax      int
+
+	// Current number of entries for each ring.
+	RX      int
+	RXMini  int
+	RXJumbo int
+	TX      int
+
+	// RXBufLen is the size of the buffers on the RX ring in bytes.
+	RXBufLen int
+	// TCPDataSplit reports whether TCP header/data split is enabled.
+	TCPDataSplit TCPDataSplit
+	// CQESize is the size of the TX/RX completion queue event in bytes.
+	CQESize int
+	// TXPush and RXPush report whether the push modes, which allow the host
+	// to write descriptors directly to the device, are enabled.
+	TXPush bool
+	RXPush bool
+	// TXPushBufLen is the size of the TX push buffer in bytes, which may not
+	// exceed TXPushBufLenMax.
+	TXPushBufLen    int
+	TXPushBufLenMax int
+}
+
+// A TCPDataSplit is the TCP header/data split setting for a Rings structure.
+type TCPDataSplit uint8
+
+// Possible TCPDataSplit values.
+const (
+	// When set, TCPDataSplitUnknown requests that the device uses its
+	// default behavior.
+	TCPDataSplitUnknown  TCPDataSplit = 0x00
+	TCPDataSplitDisabled TCPDataSplit = 0x01
+	TCPDataSplitEnabled  TCPDataSplit = 0x02
+)
+
+// String implements fmt.Stringer.
+func (t TCPDataSplit) String() string {
+	switch t {
+	case TCPDataSplitUnknown:
+		return "Unknown"
+	case TCPDataSplitDisabled:
+		return "Disabled"
+	case TCPDataSplitEnabled:
+		return "Enabled"
+	default:
+		return "Invalid"
+	}
+}
+
+// AllRings fetches Rings structures for each ethtool-supported interface on
+// this system.
+func (c *Client) AllRings() ([]*Rings, error) {
+	return c.c.AllRings()
+}
+
+// Rings fetches Rings data for the specified Interface.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) Rings(ifi Interface) (*Rings, error) {
+	return c.c.Rings(ifi)
+}
+
+// RingsUpdate represents the ring parameters of an interface to be updated.
+// Only non-nil values will be modified.
+type RingsUpdate struct {
+	RX           *int
+	RXMini       *int
+	RXJumbo      *int
+	TX           *int
+	RXBufLen     *int
+	TCPDataSplit *TCPDataSplit
+	CQESize      *int
+	TXPush       *bool
+	RXPush       *bool
+	TXPushBufLen *int
+}
+
+// SetRings updates the given Interface with the non-nil ring parameters in
+// the RingsUpdate.
+//
+// Setting ring parameters requires elevated privileges and if the caller
+// does not have permission, an error compatible with errors.Is(err,
+// os.ErrPermission) will be returned.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) SetRings(ifi Interface, ru *RingsUpdate) error {
+	return c.c.SetRings(ifi, ru)
+}
+
+// Channels contains the number of channels (queues) for an Ethernet
+// interface. Combined channels are used for both RX and TX, while other
+// channels are used for purposes such as link interrupts or SR-IOV
+// coordination.
+type Channels struct {
+	Interface Interface
+
+	// Maximum supported number of channels of each type.
+	RXMax       int
+	TXMax       int
+	OtherMax    int
+	CombinedMax int
+
+	// Current number of channels of each type.
+	RX       int
+	TX       int
+	Other    int
+	Combined int
+}
+
+// AllChannels fetches Channels structures for each ethtool-supported
+// interface on this system.
+func (c *Client) AllChannels() ([]*Channels, error) {
+	return c.c.AllChannels()
+}
+
+// Channels fetches Channels data for the specified Interface.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) Channels(ifi Interface) (*Channels, error) {
+	return c.c.Channels(ifi)
+}
+
+// ChannelsUpdate represents the channel counts of an interface to be updated.
+// Only non-nil values will be modified.
+type ChannelsUpdate struct {
+	RX       *int
+	TX       *int
+	Other    *int
+	Combined *int
+}
+
+// SetChannels updates the given Interface with the non-nil channel counts in
+// the ChannelsUpdate.
+//
+// If a requested count exceeds the maximum supported by the interface, an
+// *Error describing the out of range count will be returned.
+//
+// Setting channel counts requires elevated privileges and if the caller does
+// not have permission, an error compatible with errors.Is(err,
+// os.ErrPermission) will be returned.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) SetChannels(ifi Interface, cu *ChannelsUpdate) error {
+	return c.c.SetChannels(ifi, cu)
+}
+
+// Coalesce contains the interrupt coalescing parameters for an Ethernet
+// interface. Parameters which are not supported by the device are reported as
+// zero values.
+//
+// Fields suffixed with Usecs are expressed in microseconds, and fields
+// containing MaxFrames are expressed as a number of frames.
+type Coalesce struct {
+	Interface Interface
+
+	// Delay and frame thresholds before an RX or TX interrupt is raised.
+	RXUsecs     int
+	RXMaxFrames int
+	TXUsecs     int
+	TXMaxFrames int
+
+	// Thresholds used instead while an interrupt is being serviced.
+	RXUsecsIRQ     int
+	RXMaxFramesIRQ int
+	TXUsecsIRQ     int
+	TXMaxFramesIRQ int
+
+	// StatsBlockUsecs is the delay between statistics block updates.
+	StatsBlockUsecs int
+
+	// AdaptiveRX and AdaptiveTX report whether the device or driver adjusts
+	// the coalescing parameters dynamically based on traffic.
+	AdaptiveRX bool
+	AdaptiveTX bool
+
+	// Thresholds used when the packet rate in packets per second is below
+	// PacketRateLow.
+	PacketRateLow  int
+	RXUsecsLow     int
+	RXMaxFramesLow int
+	TXUsecsLow     int
+	TXMaxFramesLow int
+
+	// Thresholds used when the packet rate in packets per second is above
+	// PacketRateHigh.
+	PacketRateHigh  int
+	RXUsecsHigh     int
+	RXMaxFramesHigh int
+	TXUsecsHigh     int
+	TXMaxFramesHigh int
+
+	// RateSampleIntervalSeconds is the interval at which the packet rate is
+	// sampled for adaptive coalescing.
+	RateSampleIntervalSeconds int
+
+	// CQEModeRX and CQEModeTX report whether the interrupt timers are reset
+	// on completion queue events rather than on packet arrival.
+	CQEModeRX bool
+	CQEModeTX bool
+
+	// Limits for TX aggregation: the maximum number of bytes and frames
+	// which may be aggregated, and the time to wait for further frames.
+	TXAggrMaxBytes  int
+	TXAggrMaxFrames int
+	TXAggrTimeUsecs int
+}
+
+// AllCoalesce fetches Coalesce structures for each ethtool-supported
+// interface on this system.
+func (c *Client) AllCoalesce() ([]*Coalesce, error) {
+	return c.c.AllCoalesce()
+}
+
+// Coalesce fetches interrupt coalescing parameters for the specified
+// Interface.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) Coalesce(ifi Interface) (*Coalesce, error) {
+	return c.c.Coalesce(ifi)
+}
+
+// CoalesceUpdate represents the interrupt coalescing parameters of an
+// interface to be updated. Only non-nil values will be sent to the kernel.
+type CoalesceUpdate struct {
+	RXUsecs     *int
+	RXMaxFrames *int
+	TXUsecs     *int
+	TXMaxFrames *int
+
+	RXUsecsIRQ     *int
+	RXMaxFramesIRQ *int
+	TXUsecsIRQ     *int
+	TXMaxFramesIRQ *int
+
+	StatsBlockUsecs *int
+
+	AdaptiveRX *bool
+	AdaptiveTX *bool
+
+	PacketRateLow  *int
+	RXUsecsLow     *int
+	RXMaxFramesLow *int
+	TXUsecsLow     *int
+	TXMaxFramesLow *int
+
+	PacketRateHigh  *int
+	RXUsecsHigh     *int
+	RXMaxFramesHigh *int
+	TXUsecsHigh     *int
+	TXMaxFramesHigh *int
+
+	RateSampleIntervalSeconds *int
+
+	CQEModeRX *bool
+	CQEModeTX *bool
+
+	TXAggrMaxBytes  *int
+	TXAggrMaxFrames *int
+	TXAggrTimeUsecs *int
+}
+
+// SetCoalesce updates the given Interface with the non-nil interrupt
+// coalescing parameters in the CoalesceUpdate. For example, fixed RX delays
+// can be applied by disabling AdaptiveRX and setting RXUsecs in the same
+// update.
+//
+// Setting coalescing parameters requires elevated privileges and if the caller
+// does not have permission, an error compatible with errors.Is(err,
+// os.ErrPermission) will be returned.
+//
+// If the requested device does not exist, is not supported by the ethtool
+// interface, or does not support one of the requested parameters, an error
+// compatible with errors.Is(err, os.ErrNotExist) will be returned.
+func (c *Client) SetCoalesce(ifi Interface, cu *CoalesceUpdate) error {
+	return c.c.SetCoalesce(ifi, cu)
+}
+
+// Pause contains the pause frame (flow control) settings for an Ethernet
+// interface.
+type Pause struct {
+	Interface Interface
+
+	// Autoneg reports whether pause frame settings are negotiated with the
+	// link partner.
+	Autoneg bool
+	// RX and TX report whether pause frames are honored on receive and sent
+	// on transmit.
+	RX bool
+	TX bool
+
+	// Stats contains pause frame statistics. It is only populated when
+	// statistics are requested and supported by the device.
+	Stats *PauseStats
+}
+
+// PauseStats contains pause frame statistics for an Ethernet interface.
+// Counters which are not supported by the device are reported as zero.
+type PauseStats struct {
+	TXFrames uint64
+	RXFrames uint64
+}
+
+// AllPause fetches Pause structures for each ethtool-supported interface on
+// this system.
+func (c *Client) AllPause() ([]*Pause, error) {
+	return c.c.AllPause()
+}
+
+// Pause fetches pause frame settings for the specified Interface.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) Pause(ifi Interface) (*Pause, error) {
+	return c.c.Pause(ifi)
+}
+
+// PauseWithStats is like Pause, but also requests pause frame statistics
+// which are returned in the Pause.Stats field.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) PauseWithStats(ifi Interface) (*Pause, error) {
+	return c.c.PauseWithStats(ifi)
+}
+
+// PauseUpdate represents the pause frame settings of an interface to be
+// updated. Only non-nil values will be modified.
+type PauseUpdate struct {
+	Autoneg *bool
+	RX      *bool
+	TX      *bool
+}
+
+// SetPause updates the given Interface with the non-nil pause frame settings
+// in the PauseUpdate.
+//
+// Setting pause frame settings requires elevated privileges and if the caller
+// does not have permission, an error compatible with errors.Is(err,
+// os.ErrPermission) will be returned.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) SetPause(ifi Interface, pu *PauseUpdate) error {
+	return c.c.SetPause(ifi, pu)
+}
+
+// EEE contains the Energy Efficient Ethernet (IEEE 802.3az) settings for an
+// Ethernet interface.
+type EEE struct {
+	Interface Interface
+	// Ours contains the link modes for which EEE is advertised, and Peer
+	// contains those advertised by the link partner.
+	Ours, Peer []AdvertisedLinkMode
+	// Active reports whether EEE was negotiated and is in use on the link.
+	Active bool
+	// Enabled reports whether EEE is enabled.
+	Enabled bool
+	// TXLPIEnabled reports whether the transmitter may enter the low power
+	// idle (LPI) state, and TXLPITimerUsecs is the idle time before it does.
+	TXLPIEnabled    bool
+	TXLPITimerUsecs int
+}
+
+// AllEEE fetches EEE structures for each ethtool-supported interface on this
+// system.
+func (c *Client) AllEEE() ([]*EEE, error) {
+	return c.c.AllEEE()
+}
+
+// EEE fetches Energy Efficient Ethernet settings for the specified Interface.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) EEE(ifi Interface) (*EEE, error) {
+	return c.c.EEE(ifi)
+}
+
+// EEEUpdate represents the Energy Efficient Ethernet settings of an interface
+// to be updated. Only non-nil values will be modified.
+type EEEUpdate struct {
+	// Advertise is a bitmask of the link modes for which EEE is advertised,
+	// using the same bit indices as AdvertisedLinkMode.Index.
+	Advertise       *big.Int
+	Enabled         *bool
+	TXLPIEnabled    *bool
+	TXLPITimerUsecs *int
+}
+
+// SetEEE updates the given Interface with the non-nil Energy Efficient
+// Ethernet settings in the EEEUpdate.
+//
+// Setting EEE requires elevated privileges and if the caller does not have
+// permission, an error compatible with errors.Is(err, os.ErrPermission) will
+// be returned.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) SetEEE(ifi Interface, eu *EEEUpdate) error {
+	return c.c.SetEEE(ifi, eu)
+}
+
+// TimestampInfo contains the packet timestamping capabilities of an Ethernet
+// interface. Each set is keyed by the kernel's name for the capability, and a
+// capability is supported when its value is true.
+type TimestampInfo struct {
+	Interface Interface
+	// Timestamping contains the supported SOF_TIMESTAMPING flags, such as
+	// "hardware-transmit" and "hardware-receive".
+	Timestamping map[string]bool
+	// TXTypes contains the supported hardware timestamping TX types, such as
+	// "on" and "onestep-sync".
+	TXTypes map[string]bool
+	// RXFilters contains the supported hardware timestamping RX filters, such
+	// as "all" and "ptpv2-event".
+	RXFilters map[string]bool
+	// PHCIndex is the index of the PTP hardware clock associated with the
+	// interface, or -1 if there is none.
+	PHCIndex int
+
+	// Stats contains hardware timestamping statistics. It is only populated
+	// when statistics are requested and supported by the device.
+	Stats *TimestampStats
+}
+
+// TimestampStats contains hardware timestamping statistics for an Ethernet
+// interface. Counters which are not supported by the device are reported as
+// zero.
+type TimestampStats struct {
+	// TXPackets is the number of packets which were successfully
+	// timestamped on transmit.
+	TXPackets uint64
+	// TXLost is the number of transmit timestamps which were requested but
+	// never received from the device.
+	TXLost uint64
+	// TXErrors is the number of transmit timestamp requests which failed.
+	TXErrors uint64
+}
+
+// AllTimestampInfo fetches TimestampInfo structures for each ethtool-supported
+// interface on this system.
+func (c *Client) AllTimestampInfo() ([]*TimestampInfo, error) {
+	return c.c.AllTimestampInfo()
+}
+
+// TimestampInfo fetches packet timestamping capabilities for the specified
+// Interface.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) TimestampInfo(ifi Interface) (*TimestampInfo, error) {
+	return c.c.TimestampInfo(ifi)
+}
+
+// TimestampInfoWithStats is like TimestampInfo, but also requests hardware
+// timestamping statistics which are returned in the TimestampInfo.Stats field.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) TimestampInfoWithStats(ifi Interface) (*TimestampInfo, error) {
+	return c.c.TimestampInfoWithStats(ifi)
+}
+
+// PHCVClocks contains the PTP hardware clock (PHC) virtual clocks of an
+// Ethernet interface.
+type PHCVClocks struct {
+	Interface Interface
+	// Indices contains the index of each virtual clock, which corresponds to
+	// a /dev/ptpN device. It is empty if the interface has no virtual clocks.
+	Indices []int
+}
+
+// AllPHCVClocks fetches PHCVClocks structures for each ethtool-supported
+// interface on this system.
+func (c *Client) AllPHCVClocks() ([]*PHCVClocks, error) {
+	return c.c.AllPHCVClocks()
+}
+
+// PHCVClocks fetches the PTP hardware clock virtual clocks for the specified
+// Interface.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) PHCVClocks(ifi Interface) (*PHCVClocks, error) {
+	return c.c.PHCVClocks(ifi)
+}
+
+// CableTestResult contains the results of a cable test for an Ethernet
+// interface.
+type CableTestResult struct {
+	Interface Interface
+	// Pairs contains the results for each twisted pair which was tested.
+	Pairs []CableTestPair
+}
+
+// A CablePair is a twisted pair within an Ethernet cable.
+type CablePair uint8
+
+// Possible CablePair values.
+const (
+	CablePairA CablePair = 0x00
+	CablePairB CablePair = 0x01
+	CablePairC CablePair = 0x02
+	CablePairD CablePair = 0x03
+)
+
+// String implements fmt.Stringer.
+func (p CablePair) String() string {
+	switch p {
+	case CablePairA:
+		return "A"
+	case CablePairB:
+		return "B"
+	case CablePairC:
+		return "C"
+	case CablePairD:
+		return "D"
+	default:
+		return "Invalid"
+	}
+}
+
+// A CableTestCode is the result of a cable test for a single pair.
+type CableTestCode uint8
+
+// Possible CableTestCode values.
+const (
+	CableTestUnspecified CableTestCode = 0x00
+	CableTestOK          CableTestCode = 0x01
+	CableTestOpen        CableTestCode = 0x02
+	// CableTestShort indicates a short circuit within the pair.
+	CableTestShort CableTestCode = 0x03
+	// CableTestCrossShort indicates a short circuit with another pair.
+	CableTestCrossShort CableTestCode = 0x04
+)
+
+// String implements fmt.Stringer.
+func (c CableTestCode) String() string {
+	switch c {
+	case CableTestUnspecified:
+		return "Unspecified"
+	case CableTestOK:
+		return "OK"
+	case CableTestOpen:
+		return "Open"
+	case CableTestShort:
+		return "Short"
+	case CableTestCrossShort:
+		return "Cross short"
+	default:
+		return "Invalid"
+	}
+}
+
+// CableTestPair contains the cable test result for a single pair.
+type CableTestPair struct {
+	Pair CablePair
+	Code CableTestCode
+	// FaultLengthCentimeters is the distance from the interface to the fault
+	// on the pair, or -1 if the device did not report one.
+	FaultLengthCentimeters int
+}
+
+// CableTest runs a cable test on the specified Interface and waits for the
+// results. The test may take several seconds and the link is down while it
+// runs. If ctx is canceled or its deadline is exceeded before the test
+// completes, ctx.Err() will be returned.
+//
+// Running a cable test requires elevated privileges and if the caller does not
+// have permission, an error compatible with errors.Is(err, os.ErrPermission)
+// will be returned.
+//
+// If the requested device does not exist or does not support cable tests, an
+// error compatible with errors.Is(err, os.ErrNotExist) will be returned.
+func (c *Client) CableTest(ctx context.Context, ifi Interface) (*CableTestResult, error) {
+	return c.c.CableTest(ctx, ifi)
+}
+
+// CableTestTDRConfig contains optional parameters for a time-domain
+// reflectometry (TDR) cable test. Only non-nil values will be sent to the
+// kernel, and the device defaults are used otherwise.
+type CableTestTDRConfig struct {
+	// The distances at which to start and stop sampling, and the distance
+	// between each sample.
+	FirstCentimeters *int
+	LastCentimeters  *int
+	StepCentimeters  *int
+	// Pair restricts the test to a single pair.
+	Pair *CablePair
+}
+
+// CableTestTDRResult contains the raw time-domain reflectometry (TDR) data
+// reported by a cable test for an Ethernet interface.
+type CableTestTDRResult struct {
+	Interface Interface
+	// PulsesMillivolts contains the amplitude of the pulses sent into the
+	// cable.
+	PulsesMillivolts []int
+	// Steps contains the distance ranges which were sampled.
+	Steps []CableTestTDRStep
+	// Pairs contains the reflection waveform sampled for each pair.
+	Pairs []CableTestTDRPair
+}
+
+// A CableTestTDRStep is a range of distances sampled by a TDR cable test.
+type CableTestTDRStep struct {
+	FirstCentimeters int
+	LastCentimeters  int
+	StepCentimeters  int
+}
+
+// CableTestTDRPair contains the reflection waveform for a single pair.
+type CableTestTDRPair struct {
+	Pair    CablePair
+	Samples []CableTestTDRSample
+}
+
+// A CableTestTDRSample is the amplitude of the reflection measured at a
+// distance along a pair.
+type CableTestTDRSample struct {
+	// DistanceCentimeters is computed from the step which preceded the
+	// sample, or -1 if the device did not report any steps.
+	DistanceCentimeters int
+	AmplitudeMillivolts int
+}
+
+// CableTestTDR runs a time-domain reflectometry (TDR) cable test on the
+// specified Interface and waits for the results. cfg may be nil to use the
+// device defaults. If ctx is canceled or its deadline is exceeded before the
+// test completes, ctx.Err() will be returned.
+//
+// Running a cable test requires elevated privileges and if the caller does not
+// have permission, an error compatible with errors.Is(err, os.ErrPermission)
+// will be returned.
+//
+// If the requested device does not exist or does not support TDR cable tests,
+// an error compatible with errors.Is(err, os.ErrNotExist) will be returned.
+func (c *Client) CableTestTDR(ctx context.Context, ifi Interface, cfg *CableTestTDRConfig) (*CableTestTDRResult, error) {
+	return c.c.CableTestTDR(ctx, ifi, cfg)
+}
+
+// TunnelInfo contains the UDP tunnel port offload tables of an Ethernet
+// interface.
+type TunnelInfo struct {
+	Interface Interface
+	UDPTables []UDPTunnelTable
+}
+
+// A UDPTunnelTable is a table of UDP ports which the device recognizes as
+// tunnel traffic, such as VXLAN, for the purpose of offloads.
+type UDPTunnelTable struct {
+	// Size is the maximum number of ports in the table.
+	Size int
+	// Types contains the tunnel types which may be added to the table.
+	Types []UDPTunnelType
+	// Ports contains the ports which are currently programmed into the
+	// device.
+	Ports []UDPTunnelPort
+}
+
+// A UDPTunnelPort is an entry in a UDPTunnelTable.
+type UDPTunnelPort struct {
+	Port int
+	Type UDPTunnelType
+}
+
+// A UDPTunnelType is a type of UDP tunnel.
+type UDPTunnelType uint32
+
+// Possible UDPTunnelType values.
+const (
+	UDPTunnelVXLAN    UDPTunnelType = 0x00
+	UDPTunnelGENEVE   UDPTunnelType = 0x01
+	UDPTunnelVXLANGPE UDPTunnelType = 0x02
+)
+
+// String implements fmt.Stringer.
+func (t UDPTunnelType) String() string {
+	switch t {
+	case UDPTunnelVXLAN:
+		return "VXLAN"
+	case UDPTunnelGENEVE:
+		return "GENEVE"
+	case UDPTunnelVXLANGPE:
+		return "VXLAN-GPE"
+	default:
+		return "Invalid"
+	}
+}
+
+// AllTunnelInfo fetches TunnelInfo structures for each ethtool-supported
+// interface on this system.
+func (c *Client) AllTunnelInfo() ([]*TunnelInfo, error) {
+	return c.c.AllTunnelInfo()
+}
+
+// TunnelInfo fetches the UDP tunnel port offload tables for the specified
+// Interface.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) TunnelInfo(ifi Interface) (*TunnelInfo, error) {
+	return c.c.TunnelInfo(ifi)
+}
+
+// Module contains the power mode settings of the transceiver module plugged
+// into an Ethernet interface.
+type Module struct {
+	Interface Interface
+	// PowerModePolicy determines how the power mode of the module is chosen.
+	PowerModePolicy ModulePowerModePolicy
+	// PowerMode is the current power mode of the module, or
+	// ModulePowerModeUnknown if no module is plugged in.
+	PowerMode ModulePowerMode
+}
+
+// A ModulePowerModePolicy determines how the power mode of a transceiver
+// module is chosen.
+type ModulePowerModePolicy uint8
+
+// Possible ModulePowerModePolicy values.
+const (
+	ModulePowerModePolicyUnknown ModulePowerModePolicy = 0x00
+	// ModulePowerModePolicyHigh always operates the module in high power
+	// mode.
+	ModulePowerModePolicyHigh ModulePowerModePolicy = 0x01
+	// ModulePowerModePolicyAuto operates the module in high power mode only
+	// while the host interface is administratively up.
+	ModulePowerModePolicyAuto ModulePowerModePolicy = 0x02
+)
+
+// String implements fmt.Stringer.
+func (p ModulePowerModePolicy) String() string {
+	switch p {
+	case ModulePowerModePolicyUnknown:
+		return "Unknown"
+	case ModulePowerModePolicyHigh:
+		return "High"
+	case ModulePowerModePolicyAuto:
+		return "Auto"
+	default:
+		return "Invalid"
+	}
+}
+
+// A ModulePowerMode is the operational power mode of a transceiver module.
+type ModulePowerMode uint8
+
+// Possible ModulePowerMode values.
+const (
+	ModulePowerModeUnknown ModulePowerMode = 0x00
+	ModulePowerModeLow     ModulePowerMode = 0x01
+	ModulePowerModeHigh    ModulePowerMode = 0x02
+)
+
+// String implements fmt.Stringer.
+func (m ModulePowerMode) String() string {
+	switch m {
+	case ModulePowerModeUnknown:
+		return "Unknown"
+	case ModulePowerModeLow:
+		return "Low"
+	case ModulePowerModeHigh:
+		return "High"
+	default:
+		return "Invalid"
+	}
+}
+
+// AllModules fetches Module structures for each ethtool-supported interface on
+// this system.
+func (c *Client) AllModules() ([]*Module, error) {
+	return c.c.AllModules()
+}
+
+// Module fetches the transceiver module power mode settings for the specified
+// Interface.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) Module(ifi Interface) (*Module, error) {
+	return c.c.Module(ifi)
+}
+
+// ModuleUpdate represents the transceiver module settings of an interface to
+// be updated. Only non-nil values will be modified.
+type ModuleUpdate struct {
+	PowerModePolicy *ModulePowerModePolicy
+}
+
+// SetModule updates the given Interface with the non-nil transceiver module
+// settings in the ModuleUpdate.
+//
+// Setting module settings requires elevated privileges and if the caller does
+// not have permission, an error compatible with errors.Is(err,
+// os.ErrPermission) will be returned.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) SetModule(ifi Interface, mu *ModuleUpdate) error {
+	return c.c.SetModule(ifi, mu)
+}
+
+// PSE contains the Power Sourcing Equipment (PSE) state of an Ethernet
+// interface which supplies power over the link.
+type PSE struct {
+	Interface Interface
+
+	// PoDL and C33 contain the state of a Power over Data Lines (IEEE 802.3
+	// clause 104) or Power over Ethernet (IEEE 802.3 clause 33) PSE, and
+	// are nil if the interface has no PSE of that kind.
+	PoDL *PoDLPSE
+	C33  *C33PSE
+
+	// PowerDomainID identifies the power domain which shares a power budget
+	// between its ports, or is 0 if not reported.
+	PowerDomainID int
+	// Priority is the priority of the port within its power domain when the
+	// budget is exhausted, from 0 through PriorityMax. Lower values have a
+	// higher priority. Both are 0 if priorities are not supported.
+	Priority    int
+	PriorityMax int
+}
+
+// PoDLPSE contains the state of a Power over Data Lines (IEEE 802.3 clause
+// 104) PSE.
+type PoDLPSE struct {
+	AdminState  PSEAdminState
+	PowerStatus PoDLPSEPowerStatus
+}
+
+// C33PSE contains the state of a Power over Ethernet (IEEE 802.3 clause 33)
+// PSE.
+type C33PSE struct {
+	AdminState  PSEAdminState
+	PowerStatus C33PSEPowerStatus
+	// PowerClass is the power class of the powered device, or 0 if not
+	// reported.
+	PowerClass int
+	// ActualPowerMilliwatts is the power currently delivered to the
+	// powered device.
+	ActualPowerMilliwatts int
+
+	// ExtState and ExtSubstate describe why power is not being delivered.
+	// The meaning of ExtSubstate depends on ExtState, and both are 0 if not
+	// reported.
+	ExtState    C33PSEExtState
+	ExtSubstate uint32
+
+	// PowerLimitMilliwatts is the maximum power which may be delivered to
+	// the port, and PowerLimitRanges contains the ranges of power limits
+	// which the port supports.
+	PowerLimitMilliwatts int
+	PowerLimitRanges     []PSEPowerLimitRange
+}
+
+// A PSEPowerLimitRange is an inclusive range of power limits supported by a
+// PSE.
+type PSEPowerLimitRange struct {
+	MinMilliwatts, MaxMilliwatts int
+}
+
+// A PSEAdminState is the administrative state of a PSE.
+type PSEAdminState uint32
+
+// Possible PSEAdminState values.
+const (
+	PSEAdminStateUnknown  PSEAdminState = 0x01
+	PSEAdminStateDisabled PSEAdminState = 0x02
+	PSEAdminStateEnabled  PSEAdminState = 0x03
+)
+
+// String implements fmt.Stringer.
+func (s PSEAdminState) String() string {
+	switch s {
+	case PSEAdminStateUnknown:
+		return "Unknown"
+	case PSEAdminStateDisabled:
+		return "Disabled"
+	case PSEAdminStateEnabled:
+		return "Enabled"
+	default:
+		return "Invalid"
+	}
+}
+
+// A PoDLPSEPowerStatus is the power detection status of a PoDL PSE.
+type PoDLPSEPowerStatus uint32
+
+// Possible PoDLPSEPowerStatus values.
+const (
+	PoDLPSEPowerStatusUnknown    PoDLPSEPowerStatus = 0x01
+	PoDLPSEPowerStatusDisabled   PoDLPSEPowerStatus = 0x02
+	PoDLPSEPowerStatusSearching  PoDLPSEPowerStatus = 0x03
+	PoDLPSEPowerStatusDelivering PoDLPSEPowerStatus = 0x04
+	PoDLPSEPowerStatusSleep      PoDLPSEPowerStatus = 0x05
+	PoDLPSEPowerStatusIdle       PoDLPSEPowerStatus = 0x06
+	PoDLPSEPowerStatusError      PoDLPSEPowerStatus = 0x07
+)
+
+// String implements fmt.Stringer.
+func (s PoDLPSEPowerStatus) String() string {
+	switch s {
+	case PoDLPSEPowerStatusUnknown:
+		return "Unknown"
+	case PoDLPSEPowerStatusDisabled:
+		return "Disabled"
+	case PoDLPSEPowerStatusSearching:
+		return "Searching"
+	case PoDLPSEPowerStatusDelivering:
+		return "Delivering"
+	case PoDLPSEPowerStatusSleep:
+		return "Sleep"
+	case PoDLPSEPowerStatusIdle:
+		return "Idle"
+	case PoDLPSEPowerStatusError:
+		return "Error"
+	default:
+		return "Invalid"
+	}
+}
+
+// A C33PSEPowerStatus is the power detection status of a clause 33 PSE.
+type C33PSEPowerStatus uint32
+
+// Possible C33PSEPowerStatus values.
+const (
+	C33PSEPowerStatusUnknown    C33PSEPowerStatus = 0x01
+	C33PSEPowerStatusDisabled   C33PSEPowerStatus = 0x02
+	C33PSEPowerStatusSearching  C33PSEPowerStatus = 0x03
+	C33PSEPowerStatusDelivering C33PSEPowerStatus = 0x04
+	C33PSEPowerStatusTest       C33PSEPowerStatus = 0x05
+	C33PSEPowerStatusFault      C33PSEPowerStatus = 0x06
+	C33PSEPowerStatusOtherFault C33PSEPowerStatus = 0x07
+)
+
+// String implements fmt.Stringer.
+func (s C33PSEPowerStatus) String() string {
+	switch s {
+	case C33PSEPowerStatusUnknown:
+		return "Unknown"
+	case C33PSEPowerStatusDisabled:
+		return "Disabled"
+	case C33PSEPowerStatusSearching:
+		return "Searching"
+	case C33PSEPowerStatusDelivering:
+		return "Delivering"
+	case C33PSEPowerStatusTest:
+		return "Test"
+	case C33PSEPowerStatusFault:
+		return "Fault"
+	case C33PSEPowerStatusOtherFault:
+		return "OtherFault"
+	default:
+		return "Invalid"
+	}
+}
+
+// A C33PSEExtState is the extended state of a clause 33 PSE, which describes
+// why power is not being delivered.
+type C33PSEExtState uint32
+
+// Possible C33PSEExtState values.
+const (
+	C33PSEExtStateErrorCondition    C33PSEExtState = 0x01
+	C33PSEExtStateMRMPSValid        C33PSEExtState = 0x02
+	C33PSEExtStateMRPSEEnable       C33PSEExtState = 0x03
+	C33PSEExtStateOptionDetectTED   C33PSEExtState = 0x04
+	C33PSEExtStateOptionVPortLim    C33PSEExtState = 0x05
+	C33PSEExtStateOverloadDetected  C33PSEExtState = 0x06
+	C33PSEExtStatePDDLLPowerType    C33PSEExtState = 0x07
+	C33PSEExtStatePowerNotAvailable C33PSEExtState = 0x08
+	C33PSEExtStateShortDetected     C33PSEExtState = 0x09
+)
+
+// String implements fmt.Stringer.
+func (s C33PSEExtState) String() string {
+	switch s {
+	case C33PSEExtStateErrorCondition:
+		return "ErrorCondition"
+	case C33PSEExtStateMRMPSValid:
+		return "MRMPSValid"
+	case C33PSEExtStateMRPSEEnable:
+		return "MRPSEEnable"
+	case C33PSEExtStateOptionDetectTED:
+		return "OptionDetectTED"
+	case C33PSEExtStateOptionVPortLim:
+		return "OptionVPortLim"
+	case C33PSEExtStateOverloadDetected:
+		return "OverloadDetected"
+	case C33PSEExtStatePDDLLPowerType:
+		return "PDDLLPowerType"
+	case C33PSEExtStatePowerNotAvailable:
+		return "PowerNotAvailable"
+	case C33PSEExtStateShortDetected:
+		return "ShortDetected"
+	default:
+		return "Invalid"
+	}
+}
+
+// AllPSE fetches PSE structures for each ethtool-supported interface on this
+// system which has Power Sourcing Equipment.
+func (c *Client) AllPSE() ([]*PSE, error) {
+	return c.c.AllPSE()
+}
+
+// PSE fetches the Power Sourcing Equipment state for the specified Interface.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) PSE(ifi Interface) (*PSE, error) {
+	return c.c.PSE(ifi)
+}
+
+// PSEUpdate represents the Power Sourcing Equipment settings of an interface
+// to be updated. Only non-nil values will be modified.
+type PSEUpdate struct {
+	// PoDLAdminControl and C33AdminControl enable or disable power delivery
+	// by a PoDL or clause 33 PSE, using PSEAdminStateEnabled or
+	// PSEAdminStateDisabled.
+	PoDLAdminControl *PSEAdminState
+	C33AdminControl  *PSEAdminState
+	// C33PowerLimitMilliwatts sets the maximum power which may be delivered
+	// to the port. It must fall within one of the PSE's PowerLimitRanges.
+	C33PowerLimitMilliwatts *int
+	// Priority sets the priority of the port within its power domain.
+	Priority *int
+}
+
+// SetPSE updates the given Interface with the non-nil Power Sourcing Equipment
+// settings in the PSEUpdate.
+//
+// Setting PSE settings requires elevated privileges and if the caller does not
+// have permission, an error compatible with errors.Is(err, os.ErrPermission)
+// will be returned.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) SetPSE(ifi Interface, pu *PSEUpdate) error {
+	return c.c.SetPSE(ifi, pu)
+}
+
+// RSS contains the receive side scaling (RSS) configuration of an RSS context
+// on an Ethernet interface.
+type RSS struct {
+	Interface Interface
+	// Context is the ID of the RSS context, or 0 for the default context.
+	Context int
+	// HashFunction is the kernel's name for the hash function, such as
+	// "toeplitz", or empty if not reported by the device.
+	HashFunction string
+	// HashKey is the key used by the hash function.
+	HashKey []byte
+	// IndirectionTable maps each hash bucket to an RX queue index.
+	IndirectionTable []int
+	// InputTransform is the transformation applied to the packet fields
+	// before hashing.
+	InputTransform RSSInputTransform
+}
+
+// An RSSInputTransform is a bitmask of transformations applied to the packet
+// fields before they are hashed.
+type RSSInputTransform uint32
+
+// Possible RSSInputTransform values.
+const (
+	// RSSInputTransformSymmetricXOR XORs the source and destination fields
+	// so both directions of a flow hash to the same queue.
+	RSSInputTransformSymmetricXOR RSSInputTransform = 1 << 0
+	// RSSInputTransformSymmetricORXOR hashes the OR and XOR of the source
+	// and destination fields, which is also symmetric.
+	RSSInputTransformSymmetricORXOR RSSInputTransform = 1 << 1
+)
+
+// RSS fetches the receive side scaling configuration of the specified RSS
+// context for the specified Interface. Context 0 is the default context.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) RSS(ifi Interface, context int) (*RSS, error) {
+	return c.c.RSS(ifi, context)
+}
+
+// RSSUpdate represents the receive side scaling configuration of an RSS
+// context to be updated. Only non-nil values will be modified.
+type RSSUpdate struct {
+	// HashFunction is the kernel's name for the hash function, such as
+	// "toeplitz".
+	HashFunction *string
+	HashKey      []byte
+	// IndirectionTable maps each hash bucket to an RX queue index. A non-nil
+	// but empty table resets the indirection table to the device default.
+	IndirectionTable []int
+	InputTransform   *RSSInputTransform
+}
+
+// SetRSS updates the specified RSS context of the given Interface with the
+// non-nil receive side scaling configuration in the RSSUpdate. Context 0 is
+// the default context. SetRSS requires Linux 6.17+.
+//
+// Setting RSS configuration requires elevated privileges and if the caller
+// does not have permission, an error compatible with errors.Is(err,
+// os.ErrPermission) will be returned.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) SetRSS(ifi Interface, context int, ru *RSSUpdate) error {
+	return c.c.SetRSS(ifi, context, ru)
+}
+
+// Queues returns the distinct RX queue indices referenced by the RSS
+// indirection table, in ascending order.
+func (r *RSS) Queues() []int {
+	qs := slices.Clone(r.IndirectionTable)
+	slices.Sort(qs)
+	return slices.Compact(qs)
+}
+
+// RSSContexts fetches the receive side scaling configuration of every RSS
+// context on the specified Interface, including the default context.
+// RSSContexts requires Linux 6.11+.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) RSSContexts(ifi Interface) ([]*RSS, error) {
+	return c.c.RSSContexts(ifi)
+}
+
+// CreateRSSContext creates an additional RSS context on the specified
+// Interface and returns its ID. The context is configured with the non-nil
+// values in ru, which may be nil to use the device defaults. Flows can then
+// be steered to the context using ntuple filters.
+//
+// On kernels older than Linux 6.17, the context is created using the ethtool
+// ioctl interface instead.
+//
+// Creating RSS contexts requires elevated privileges and if the caller does
+// not have permission, an error compatible with errors.Is(err,
+// os.ErrPermission) will be returned.
+func (c *Client) CreateRSSContext(ifi Interface, ru *RSSUpdate) (int, error) {
+	return c.c.CreateRSSContext(ifi, ru)
+}
+
+// DeleteRSSContext deletes the specified additional RSS context from the
+// Interface.
+//
+// On kernels older than Linux 6.17, the context is deleted using the ethtool
+// ioctl interface instead.
+//
+// Deleting RSS contexts requires elevated privileges and if the caller does
+// not have permission, an error compatible with errors.Is(err,
+// os.ErrPermission) will be returned.
+func (c *Client) DeleteRSSContext(ifi Interface, context int) error {
+	return c.c.DeleteRSSContext(ifi, context)
+}
+
+// PLCAConfig contains the Physical Layer Collision Avoidance (PLCA, IEEE 802.3
+// clause 148) configuration of a 10BASE-T1S multidrop Ethernet interface.
+// Settings which are not supported by the PHY are reported as zero.
+type PLCAConfig struct {
+	Interface Interface
+	// Version is the OPEN Alliance PLCA register map identifier and version,
+	// as reported in the PHY's IDVER register.
+	Version uint16
+	Enabled bool
+	// NodeID is the ID of this node on the segment, where 0 is the
+	// coordinator and 255 means the node is not configured.
+	NodeID int
+	// NodeCount is the maximum number of nodes on the segment, which is only
+	// meaningful on the coordinator.
+	NodeCount int
+	// TOTimerBitTimes is the duration of each transmit opportunity.
+	TOTimerBitTimes int
+	// BurstCount is the number of additional packets a node may transmit in
+	// a single transmit opportunity, and BurstTimerBitTimes is the time to
+	// wait for the next packet of a burst.
+	BurstCount         int
+	BurstTimerBitTimes int
+}
+
+// PLCAConfig fetches the PLCA configuration for the specified Interface.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) PLCAConfig(ifi Interface) (*PLCAConfig, error) {
+	return c.c.PLCAConfig(ifi)
+}
+
+// PLCAConfigUpdate represents the PLCA configuration of an interface to be
+// updated. Only non-nil values will be modified.
+type PLCAConfigUpdate struct {
+	Enabled            *bool
+	NodeID             *int
+	NodeCount          *int
+	TOTimerBitTimes    *int
+	BurstCount         *int
+	BurstTimerBitTimes *int
+}
+
+// SetPLCAConfig updates the given Interface with the non-nil PLCA settings in
+// the PLCAConfigUpdate.
+//
+// Setting the PLCA configuration requires elevated privileges and if the
+// caller does not have permission, an error compatible with errors.Is(err,
+// os.ErrPermission) will be returned.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) SetPLCAConfig(ifi Interface, pu *PLCAConfigUpdate) error {
+	return c.c.SetPLCAConfig(ifi, pu)
+}
+
+// PLCAStatus contains the PLCA status of a 10BASE-T1S multidrop Ethernet
+// interface.
+type PLCAStatus struct {
+	Interface Interface
+	// Active reports whether the node is receiving BEACONs from the
+	// coordinator, or sending them if it is the coordinator.
+	Active bool
+}
+
+// PLCAStatus fetches the PLCA status for the specified Interface.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) PLCAStatus(ifi Interface) (*PLCAStatus, error) {
+	return c.c.PLCAStatus(ifi)
+}
+
+// Common I2C addresses of transceiver module EEPROMs.
+const (
+	// I2CAddressA0 is the address of the serial ID and control pages, also
+	// known as A0h.
+	I2CAddressA0 = 0x50
+	// I2CAddressA2 is the address of the SFF-8472 diagnostic pages, also
+	// known as A2h.
+	I2CAddressA2 = 0x51
+)
+
+// A ModuleEEPROMRequest specifies a region of a transceiver module EEPROM to
+// read. Each page is 128 bytes, and the first 128 bytes of the EEPROM (the
+// lower page) are always accessible regardless of the selected page.
+type ModuleEEPROMRequest struct {
+	// Offset is the offset within the 256 bytes formed by the lower page
+	// and the selected upper page. When Page is non-zero, Offset must be at
+	// least 128.
+	Offset int
+	// Length is the number of bytes to read. A single read may not cross the
+	// 128 byte boundary between the lower and upper page.
+	Length int
+	// Page and Bank select the upper page to read.
+	Page int
+	Bank int
+	// I2CAddress is the 7-bit I2C address of the EEPROM, such as
+	// I2CAddressA0.
+	I2CAddress int
+}
+
+// ModuleEEPROM reads raw data from the transceiver module EEPROM of the
+// specified Interface.
+//
+// Reading module EEPROMs requires elevated privileges and if the caller does
+// not have permission, an error compatible with errors.Is(err,
+// os.ErrPermission) will be returned.
+//
+// If the requested device does not exist or has no module EEPROM, an error
+// compatible with errors.Is(err, os.ErrNotExist) will be returned.
+func (c *Client) ModuleEEPROM(ifi Interface, req ModuleEEPROMRequest) ([]byte, error) {
+	return c.c.ModuleEEPROM(ifi, req)
+}
+
+// moduleEEPROMPageLen is the length of a module EEPROM page.
+const moduleEEPROMPageLen = 128
+
+var _ io.ReaderAt = &ModuleEEPROMReader{}
+
+// A ModuleEEPROMReader is an io.ReaderAt which reads the paged memory of a
+// transceiver module EEPROM as a flat address space, as used by SFF-8472,
+// SFF-8636, and CMIS.
+//
+// Offsets 0 through 255 address the lower page and upper page 00h. Each
+// following 128 bytes address the next upper page, so upper page N begins at
+// offset 128*(N+1).
+type ModuleEEPROMReader struct {
+	c          *Client
+	ifi        Interface
+	i2cAddress int
+	bank       int
+}
+
+// ModuleEEPROMReader returns a ModuleEEPROMReader for the EEPROM at the
+// specified I2C address and bank of the transceiver module on the Interface.
+// Most modules only have a single bank, numbered 0.
+func (c *Client) ModuleEEPROMReader(ifi Interface, i2cAddress, bank int) *ModuleEEPROMReader {
+	return &ModuleEEPROMReader{
+		c:          c,
+		ifi:        ifi,
+		i2cAddress: i2cAddress,
+		bank:       bank,
+	}
+}
+
+// ReadAt implements io.ReaderAt. Reads which span multiple pages are split
+// into one request per page.
+func (r *ModuleEEPROMReader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("ethtool: invalid module EEPROM offset: %d", off)
+	}
+
+	// The last addressable byte is the end of upper page 255.
+	const size = moduleEEPROMPageLen * 257
+
+	var n int
+	for n < len(p) {
+		addr := off + int64(n)
+		if addr >= size {
+			return n, io.EOF
+		}
+
+		// Determine which page this address belongs to and how much of the
+		// page remains.
+		req := ModuleEEPROMRequest{
+			Bank:       r.bank,
+			I2CAddress: r.i2cAddress,
+		}
+		if addr < 2*moduleEEPROMPageLen {
+			req.Offset = int(addr)
+		} else {
+			req.Page = int(addr/moduleEEPROMPageLen) - 1
+			req.Offset = moduleEEPROMPageLen + int(addr%moduleEEPROMPageLen)
+		}
+		req.Length = min(
+			len(p)-n,
+			moduleEEPROMPageLen-req.Offset%moduleEEPROMPageLen,
+		)
+
+		b, err := r.c.ModuleEEPROM(r.ifi, req)
+		if err != nil {
+			return n, err
+		}
+		if len(b) == 0 {
+			return n, io.ErrUnexpectedEOF
+		}
+
+		n += copy(p[n:], b)
+	}
+
+	return n, nil
+}
+
+// A StatsGroup is a group of standard statistics which may be requested from
+// a device.
+type StatsGroup int
+
+// Possible StatsGroup values.
+const (
+	// StatsEthPHY contains the IEEE 802.3 PHY counters.
+	StatsEthPHY StatsGroup = iota
+	// StatsEthMAC contains the IEEE 802.3 MAC counters.
+	StatsEthMAC
+	// StatsEthCtrl contains the IEEE 802.3 MAC control counters.
+	StatsEthCtrl
+	// StatsRMON contains the RMON (RFC 2819) counters and packet size
+	// histograms.
+	StatsRMON
+)
+
+// String returns the kernel's name for a StatsGroup.
+func (g StatsGroup) String() string {
+	switch g {
+	case StatsEthPHY:
+		return "eth-phy"
+	case StatsEthMAC:
+		return "eth-mac"
+	case StatsEthCtrl:
+		return "eth-ctrl"
+	case StatsRMON:
+		return "rmon"
+	default:
+		return "Invalid"
+	}
+}
+
+// A StatsSource selects which MAC statistics are reported by a device which
+// supports the MAC Merge layer (IEEE 802.3 clause 99).
+type StatsSource int
+
+// Possible StatsSource values.
+const (
+	// StatsSourceAggregate reports the sum of the express and preemptible
+	// MAC statistics, or the statistics of the only MAC of a device without
+	// MAC Merge support.
+	StatsSourceAggregate StatsSource = iota
+	// StatsSourceEMAC reports the statistics of the express MAC.
+	StatsSourceEMAC
+	// StatsSourcePMAC reports the statistics of the preemptible MAC.
+	StatsSourcePMAC
+)
+
+// A StatsRequest selects the standard statistics to fetch from a device.
+type StatsRequest struct {
+	// Groups selects the groups of statistics to fetch. If empty, all
+	// groups are requested.
+	Groups []StatsGroup
+	// Source selects the MAC whose statistics are reported. Selecting a
+	// source other than StatsSourceAggregate requires Linux 6.4+.
+	Source StatsSource
+}
+
+// Stats contains the standard statistics of an Ethernet interface. Unlike
+// driver-specific statistics, these counters have the same meaning on every
+// device which reports them.
+//
+// Each group is nil if it was not requested. Counters which are not supported
+// by the device are reported as zero.
+type Stats struct {
+	Interface Interface
+	EthPHY    *EthPHYStats
+	EthMAC    *EthMACStats
+	EthCtrl   *EthCtrlStats
+	RMON      *RMONStats
+}
+
+// EthPHYStats contains the IEEE 802.3 PHY counters of an Ethernet interface.
+type EthPHYStats struct {
+	SymbolErrorDuringCarrier uint64
+}
+
+// EthMACStats contains the IEEE 802.3 MAC counters of an Ethernet interface.
+// Field names follow the names of the managed objects in IEEE 802.3 clause
+// 30.
+type EthMACStats struct {
+	FramesTransmittedOK            uint64
+	SingleCollisionFrames          uint64
+	MultipleCollisionFrames        uint64
+	FramesReceivedOK               uint64
+	FrameCheckSequenceErrors       uint64
+	AlignmentErrors                uint64
+	OctetsTransmittedOK            uint64
+	FramesWithDeferredXmissions    uint64
+	LateCollisions                 uint64
+	FramesAbortedDueToXSColls      uint64
+	FramesLostDueToIntMACXmitError uint64
+	CarrierSenseErrors             uint64
+	OctetsReceivedOK               uint64
+	FramesLostDueToIntMACRcvError  uint64
+	MulticastFramesXmittedOK       uint64
+	BroadcastFramesXmittedOK       uint64
+	FramesWithExcessiveDeferral    uint64
+	MulticastFramesReceivedOK      uint64
+	BroadcastFramesReceivedOK      uint64
+	InRangeLengthErrors            uint64
+	OutOfRangeLengthField          uint64
+	FrameTooLongErrors             uint64
+}
+
+// EthCtrlStats contains the IEEE 802.3 MAC control counters of an Ethernet
+// interface.
+type EthCtrlStats struct {
+	MACControlFramesTransmitted uint64
+	MACControlFramesReceived    uint64
+	UnsupportedOpcodesReceived  uint64
+}
+
+// RMONStats contains the RMON (RFC 2819) counters of an Ethernet interface.
+type RMONStats struct {
+	UndersizePkts uint64
+	OversizePkts  uint64
+	Fragments     uint64
+	Jabbers       uint64
+
+	// RXHistogram and TXHistogram contain the number of received and
+	// transmitted packets in each of the device's packet size ranges.
+	RXHistogram []RMONHistogramBucket
+	TXHistogram []RMONHistogramBucket
+}
+
+// An RMONHistogramBucket contains the number of packets with a size between
+// Low and High bytes, inclusive.
+type RMONHistogramBucket struct {
+	Low, High int
+	Packets   uint64
+}
+
+// AllStats fetches Stats structures for each ethtool-supported interface on
+// this system.
+func (c *Client) AllStats(req StatsRequest) ([]*Stats, error) {
+	return c.c.AllStats(req)
+}
+
+// Stats fetches standard statistics for the specified Interface.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) Stats(ifi Interface, req StatsRequest) (*Stats, error) {
+	return c.c.Stats(ifi, req)
+}
+
+// A StringSetID identifies one of the kernel's string sets, which contain the
+// names of features, statistics, link modes, and more.
+type StringSetID int
+
+// Possible StringSetID values.
+const (
+	StringSetTest               StringSetID = 0
+	StringSetStats              StringSetID = 1
+	StringSetPrivateFlags       StringSetID = 2
+	StringSetNtupleFilters      StringSetID = 3
+	StringSetFeatures           StringSetID = 4
+	StringSetRSSHashFuncs       StringSetID = 5
+	StringSetTunables           StringSetID = 6
+	StringSetPHYStats           StringSetID = 7
+	StringSetPHYTunables        StringSetID = 8
+	StringSetLinkModes          StringSetID = 9
+	StringSetMessageClasses     StringSetID = 10
+	StringSetWOLModes           StringSetID = 11
+	StringSetSOFTimestamping    StringSetID = 12
+	StringSetTimestampTXTypes   StringSetID = 13
+	StringSetTimestampRXFilters StringSetID = 14
+	StringSetUDPTunnelTypes     StringSetID = 15
+	StringSetStatsStandard      StringSetID = 16
+	StringSetStatsEthPHY        StringSetID = 17
+	StringSetStatsEthMAC        StringSetID = 18
+	StringSetStatsEthCtrl       StringSetID = 19
+	StringSetStatsRMON          StringSetID = 20
+	StringSetStatsPHY           StringSetID = 21
+	StringSetTimestampFlags     StringSetID = 22
+)
+
+// A StringSet is a table of strings produced by the kernel. The position of
+// each string in Strings is its index within the set, which typically
+// corresponds to a bit in an ethtool bitset.
+type StringSet struct {
+	ID      StringSetID
+	Strings []string
+}
+
+// StringSets fetches the string sets identified by ids. If ifi is the zero
+// value, the global string sets which do not depend on a device are queried.
+// Otherwise, the string sets for the specified Interface are queried, which
+// also allows fetching device-specific sets such as StringSetPrivateFlags and
+// StringSetStats.
+//
+// If ids is empty, the kernel returns all of the global string sets when ifi is
+// the zero value, or all of the device-specific string sets otherwise.
+//
+// If the requested device does not exist or is not supported by the ethtool
+// interface, an error compatible with errors.Is(err, os.ErrNotExist) will be
+// returned.
+func (c *Client) StringSets(ifi Interface, ids ...StringSetID) ([]*StringSet, error) {
+	return c.c.StringSets(ifi, ids...)
+}
+
+// An Event is a change notification produced by the kernel and received from
+// the ethtool monitor multicast group. The concrete type of an Event is a
+// pointer to one of the structures returned by the Client's getters, such as
+// *LinkMode or *WakeOnLAN, and callers can use a type switch to determine which
+// setting changed.
+type Event interface {
+	event()
+}
+
+func (*LinkInfo) event()     {}
+func (*LinkMode) event()     {}
+func (*WakeOnLAN) event()    {}
+func (*FEC) event()          {}
+func (*PrivateFlags) event() {}
+func (*Features) event()     {}
+func (*Rings) event()        {}
+func (*Channels) event()     {}
+func (*Coalesce) event()     {}
+func (*Pause) event()        {}
+func (*EEE) event()          {}
+func (*Module) event()       {}
+func (*PLCAConfig) event()   {}
+
+// Monitor joins the ethtool monitor multicast group using a dedicated
+// connection and returns an iterator of Events which are produced whenever the
+// settings of an ethtool-supported interface change. Notifications which do not
+// correspond to a known Event type are skipped.
+//
+// The iterator stops when the caller breaks out of the loop, when an error
+// occurs, or when ctx is canceled, in which case ctx.Err() is yielded as the
+// final error.
+func (c *Client) Monitor(ctx context.Context) iter.Seq2[Event, error] {
+	return c.c.Monitor(ctx)
+}
+
 // Close cleans up the Client's resources.
 func (c *Client) Close() error { return c.c.Close() }
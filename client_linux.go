@@ -4,11 +4,17 @@
 package ethtool
 
 import (
+	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"iter"
+	"maps"
+	"math/big"
 	"os"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/mdlayher/genetlink"
 	"github.com/mdlayher/netlink"
@@ -24,6 +30,14 @@ type client struct {
 	c         *genetlink.Conn
 	family    uint16
 	monitorID uint32
+
+	// dialMonitor opens a dedicated connection which has joined the monitor
+	// multicast group. It can be swapped out in tests.
+	dialMonitor func() (*genetlink.Conn, error)
+
+	// ioctl issues a request using the legacy ethtool ioctl interface. It
+	// can be swapped out in tests.
+	ioctl func(ifi Interface, b []byte) error
 }
 
 // Note that some Client methods may panic if the kernel returns an unexpected
@@ -72,9 +86,30 @@ func initClient(c *genetlink.Conn) (*client, error) {
 		c:         c,
 		family:    f.ID,
 		monitorID: monitorID,
+		dialMonitor: func() (*genetlink.Conn, error) {
+			return dialMonitor(monitorID)
+		},
+		ioctl: ethtoolIoctl,
 	}, nil
 }
 
+// dialMonitor opens a generic netlink connection and joins the ethtool monitor
+// multicast group. Notifications are received on a separate connection so they
+// are never interleaved with the replies to requests made by a client.
+func dialMonitor(monitorID uint32) (*genetlink.Conn, error) {
+	conn, err := genetlink.Dial(&netlink.Config{Strict: true})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conn.JoinGroup(monitorID); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
 // Close closes the underlying generic netlink connection.
 func (c *client) Close() error { return c.c.Close() }
 
@@ -179,22 +214,28 @@ func (lmu *LinkModeUpdate) encode(ae *netlink.AttributeEncoder) {
 		ae.Uint8(unix.ETHTOOL_A_LINKMODES_AUTONEG, uint8(*lmu.Autoneg))
 	}
 	if lmu.Advertise != nil {
-		ae.Nested(unix.ETHTOOL_A_LINKMODES_OURS, func(nae *netlink.AttributeEncoder) error {
-			nae.Flag(unix.ETHTOOL_A_BITSET_NOMASK, true)
-			bitlen := lmu.Advertise.BitLen()
-			nae.Uint32(unix.ETHTOOL_A_BITSET_SIZE, uint32(bitlen))
-			b := make([]byte, ((bitlen+31)/32)*4)
-			b = lmu.Advertise.FillBytes(b)
-			if !cpu.IsBigEndian {
-				// FillBytes is big endian, reverse bytes for host order
-				slices.Reverse(b)
-			}
-			nae.Bytes(unix.ETHTOOL_A_BITSET_VALUE, b)
-			return nil
-		})
+		encodeLinkModes(ae, unix.ETHTOOL_A_LINKMODES_OURS, lmu.Advertise)
 	}
 }
 
+// encodeLinkModes packs a bitmask of link modes into a compact bitset
+// attribute of the specified type, overwriting any existing link modes.
+func encodeLinkModes(ae *netlink.AttributeEncoder, typ uint16, modes *big.Int) {
+	ae.Nested(typ, func(nae *netlink.AttributeEncoder) error {
+		nae.Flag(unix.ETHTOOL_A_BITSET_NOMASK, true)
+		bitlen := modes.BitLen()
+		nae.Uint32(unix.ETHTOOL_A_BITSET_SIZE, uint32(bitlen))
+		b := make([]byte, ((bitlen+31)/32)*4)
+		b = modes.FillBytes(b)
+		if !cpu.IsBigEndian {
+			// FillBytes is big endian, reverse bytes for host order
+			slices.Reverse(b)
+		}
+		nae.Bytes(unix.ETHTOOL_A_BITSET_VALUE, b)
+		return nil
+	})
+}
+
 // LinkStates fetches link state data for all ethtool-supported links.
 func (c *client) LinkStates() ([]*LinkState, error) {
 	return c.linkState(netlink.Dump, Interface{})
@@ -234,7 +275,18 @@ func (c *client) linkState(flags netlink.HeaderFlags, ifi Interface) ([]*LinkSta
 // FEC fetches the forward error correction (FEC) setting for a single
 // ethtool-supported link.
 func (c *client) FEC(ifi Interface) (*FEC, error) {
-	fecs, err := c.fec(0, ifi)
+	return c.fecOne(0, ifi)
+}
+
+// FECWithStats fetches the forward error correction (FEC) setting and
+// statistics for a single ethtool-supported link.
+func (c *client) FECWithStats(ifi Interface) (*FEC, error) {
+	return c.fecOne(unix.ETHTOOL_FLAG_STATS, ifi)
+}
+
+// fecOne is the shared logic for Client.FEC(WithStats).
+func (c *client) fecOne(hflags uint32, ifi Interface) (*FEC, error) {
+	fecs, err := c.fec(0, hflags, ifi)
 	if err != nil {
 		return nil, err
 	}
@@ -248,11 +300,12 @@ func (c *client) FEC(ifi Interface) (*FEC, error) {
 }
 
 // fec is the shared logic for Client.FEC(s).
-func (c *client) fec(flags netlink.HeaderFlags, ifi Interface) ([]*FEC, error) {
-	msgs, err := c.get(
+func (c *client) fec(flags netlink.HeaderFlags, hflags uint32, ifi Interface) ([]*FEC, error) {
+	msgs, err := c.getFlags(
 		_ETHTOOL_A_FEC_HEADER,
 		unix.ETHTOOL_MSG_FEC_GET,
 		flags,
+		hflags,
 		ifi,
 		nil,
 	)
@@ -503,7 +556,7 @@ func parsePrivateFlags(msgs []genetlink.Message) ([]*PrivateFlags, error) {
 			case unix.ETHTOOL_A_PRIVFLAGS_HEADER:
 				ad.Nested(parseInterface(&privFlags.Interface))
 			case unix.ETHTOOL_A_PRIVFLAGS_FLAGS:
-				ad.Nested(parsePrivateFlagBitset(&privFlags.Flags))
+				ad.Nested(parseNamedBitset(&privFlags.Flags))
 			}
 		}
 
@@ -517,11 +570,17 @@ func parsePrivateFlags(msgs []genetlink.Message) ([]*PrivateFlags, error) {
 	return wols, nil
 }
 
-func parsePrivateFlagBitset(p *map[string]bool) func(*netlink.AttributeDecoder) error {
+// parseNamedBitset decodes an ethtool verbose bitset into a map of bit names
+// and their values. If the bitset is a list (no mask), every bit present in the
+// list is set. Bits without a name are skipped.
+func parseNamedBitset(p *map[string]bool) func(*netlink.AttributeDecoder) error {
 	return func(ad *netlink.AttributeDecoder) error {
+		var list bool
 		flags := make(map[string]bool)
 		for ad.Next() {
 			switch ad.Type() {
+			case unix.ETHTOOL_A_BITSET_NOMASK:
+				list = true
 			case unix.ETHTOOL_A_BITSET_BITS:
 				ad.Nested(func(nad *netlink.AttributeDecoder) error {
 					for nad.Next() {
@@ -529,7 +588,7 @@ func parsePrivateFlagBitset(p *map[string]bool) func(*netlink.AttributeDecoder)
 						case unix.ETHTOOL_A_BITSET_BITS_BIT:
 							nad.Nested(func(nnad *netlink.AttributeDecoder) error {
 								var name string
-								var active bool
+								active := list
 								for nnad.Next() {
 									switch nnad.Type() {
 									case unix.ETHTOOL_A_BITSET_BIT_NAME:
@@ -538,7 +597,9 @@ func parsePrivateFlagBitset(p *map[string]bool) func(*netlink.AttributeDecoder)
 										active = true
 									}
 								}
-								flags[name] = active
+								if name != "" {
+									flags[name] = active
+								}
 								return nnad.Err()
 							})
 						}
@@ -552,15 +613,17 @@ func parsePrivateFlagBitset(p *map[string]bool) func(*netlink.AttributeDecoder)
 	}
 }
 
-// encode packs PrivateFlags data into the appropriate netlink attributes for the
-// encoder.
-func (pf *PrivateFlags) encode(ae *netlink.AttributeEncoder) {
-	ae.Nested(unix.ETHTOOL_A_PRIVFLAGS_FLAGS, func(nae *netlink.AttributeEncoder) error {
+// encodeNamedBitset packs a map of bit names and their values into an ethtool
+// verbose bitset with the specified attribute type. Bits which are not present
+// in the map are left as-is by the kernel.
+func encodeNamedBitset(ae *netlink.AttributeEncoder, typ uint16, bits map[string]bool) {
+	ae.Nested(typ, func(nae *netlink.AttributeEncoder) error {
 		nae.Nested(unix.ETHTOOL_A_BITSET_BITS, func(nnae *netlink.AttributeEncoder) error {
-			for name, active := range pf.Flags {
+			// Sort the names so the output is stable.
+			for _, name := range slices.Sorted(maps.Keys(bits)) {
 				nnae.Nested(unix.ETHTOOL_A_BITSET_BITS_BIT, func(nnnae *netlink.AttributeEncoder) error {
 					nnnae.String(unix.ETHTOOL_A_BITSET_BIT_NAME, name)
-					nnnae.Flag(unix.ETHTOOL_A_BITSET_BIT_VALUE, active)
+					nnnae.Flag(unix.ETHTOOL_A_BITSET_BIT_VALUE, bits[name])
 					return nil
 				})
 			}
@@ -570,134 +633,108 @@ func (pf *PrivateFlags) encode(ae *netlink.AttributeEncoder) {
 	})
 }
 
-// get performs a request/response interaction with ethtool netlink.
-func (c *client) get(
-	header uint16,
-	cmd uint8,
-	flags netlink.HeaderFlags,
-	ifi Interface,
-	// May be nil; used to apply optional parameters.
-	params func(ae *netlink.AttributeEncoder),
-) ([]genetlink.Message, error) {
-	if flags&netlink.Dump == 0 && ifi.Index == 0 && ifi.Name == "" {
-		// The caller is not requesting to dump information for multiple
-		// interfaces and thus has to specify some identifier or the kernel will
-		// EINVAL on this path.
-		return nil, errBadRequest
-	}
-
-	// TODO(mdlayher): make this faster by potentially precomputing the byte
-	// slice of packed netlink attributes and then modifying the index value at
-	// the appropriate byte slice index.
-	ae := netlink.NewAttributeEncoder()
-	ae.Nested(header, func(nae *netlink.AttributeEncoder) error {
-		// When fetching by index or name, one or both will be non-zero.
-		// Otherwise we leave the header empty to dump all the links.
-		//
-		// Note that if the client happens to pass an incompatible non-zero
-		// index/name pair, the kernel will return an error and we'll
-		// immediately send that back.
-		if ifi.Index > 0 {
-			nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(ifi.Index))
-		}
-		if ifi.Name != "" {
-			nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, ifi.Name)
-		}
-
-		// Unconditionally add the compact bitsets flag to all query commands
-		// since the ethtool multicast group notifications require the compact
-		// format, so we might as well always use it.
-		if cmd != unix.ETHTOOL_MSG_FEC_SET &&
-			cmd != unix.ETHTOOL_MSG_WOL_SET &&
-			cmd != unix.ETHTOOL_MSG_PRIVFLAGS_GET &&
-			cmd != unix.ETHTOOL_MSG_PRIVFLAGS_SET {
-			nae.Uint32(unix.ETHTOOL_A_HEADER_FLAGS, unix.ETHTOOL_FLAG_COMPACT_BITSETS)
-		}
-
-		return nil
-	})
+// encode packs PrivateFlags data into the appropriate netlink attributes for the
+// encoder.
+func (pf *PrivateFlags) encode(ae *netlink.AttributeEncoder) {
+	encodeNamedBitset(ae, unix.ETHTOOL_A_PRIVFLAGS_FLAGS, pf.Flags)
+}
 
-	if params != nil {
-		// Optionally apply more parameters to the attribute encoder.
-		params(ae)
-	}
+// AllFeatures fetches Features for all ethtool-supported links.
+func (c *client) AllFeatures() ([]*Features, error) {
+	return c.features(netlink.Dump, Interface{})
+}
 
-	// Note: don't send netlink.Acknowledge or we get an extra message back from
-	// the kernel which doesn't seem useful as of now.
-	msgs, err := c.execute(cmd, flags, ae)
+// Features fetches Features for a single interface.
+func (c *client) Features(ifi Interface) (*Features, error) {
+	fs, err := c.features(0, ifi)
 	if err != nil {
-		// Unpack the extended acknowledgement error message if possible so the
-		// caller doesn't have to unpack it themselves.
-		var msg string
-		if oerr, ok := err.(*netlink.OpError); ok {
-			msg = oerr.Message
-		}
+		return nil, err
+	}
 
-		return nil, &Error{
-			Message: msg,
-			Err:     err,
-		}
+	if l := len(fs); l != 1 {
+		panicf("ethtool: unexpected number of Features messages for request index: %d, name: %q: %d",
+			ifi.Index, ifi.Name, l)
 	}
 
-	return msgs, nil
+	return fs[0], nil
 }
 
-// execute executes the specified command with additional header flags and input
-// netlink request attributes. The netlink.Request header flag is automatically
-// set.
-func (c *client) execute(cmd uint8, flags netlink.HeaderFlags, ae *netlink.AttributeEncoder) ([]genetlink.Message, error) {
-	b, err := ae.Encode()
+// features is the shared logic for Client.(All)Features.
+func (c *client) features(flags netlink.HeaderFlags, ifi Interface) ([]*Features, error) {
+	msgs, err := c.get(
+		unix.ETHTOOL_A_FEATURES_HEADER,
+		unix.ETHTOOL_MSG_FEATURES_GET,
+		flags,
+		ifi,
+		nil,
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.c.Execute(
-		genetlink.Message{
-			Header: genetlink.Header{
-				Command: cmd,
-				Version: unix.ETHTOOL_GENL_VERSION,
-			},
-			Data: b,
+	return parseFeatures(msgs)
+}
+
+// SetFeatures requests changes to the wanted features of a single interface
+// and reports the changes which the kernel could not apply.
+func (c *client) SetFeatures(ifi Interface, features map[string]bool) (map[string]bool, error) {
+	// Unlike most set commands, the kernel replies with a message describing
+	// the result of the request, so we don't request an acknowledgement.
+	msgs, err := c.get(
+		unix.ETHTOOL_A_FEATURES_HEADER,
+		unix.ETHTOOL_MSG_FEATURES_SET,
+		0,
+		ifi,
+		func(ae *netlink.AttributeEncoder) {
+			encodeNamedBitset(ae, unix.ETHTOOL_A_FEATURES_WANTED, features)
 		},
-		// Always pass the genetlink family ID and request flag.
-		c.family,
-		netlink.Request|flags,
 	)
-}
+	if err != nil {
+		return nil, err
+	}
 
-// Is enables Error comparison with sentinel errors that are part of the
-// Client's API contract such as os.ErrNotExist and os.ErrPermission.
-func (e *Error) Is(target error) bool {
-	switch target {
-	case os.ErrNotExist:
-		// The queried interface is not supported by the ethtool APIs
-		// (EOPNOTSUPP) or does not exist at all (ENODEV).
-		return errors.Is(e.Err, unix.EOPNOTSUPP) || errors.Is(e.Err, unix.ENODEV)
-	case os.ErrPermission:
-		// The caller lacks permission to perform an operation.
-		return errors.Is(e.Err, unix.EPERM)
-	default:
-		return false
+	fs, err := parseFeatures(msgs)
+	if err != nil {
+		return nil, err
+	}
+
+	if l := len(fs); l != 1 {
+		panicf("ethtool: unexpected number of Features messages for request index: %d, name: %q: %d",
+			ifi.Index, ifi.Name, l)
+	}
+
+	// The wanted bitset in the reply consists of the requested bits which
+	// differ from the result, with the values from the request.
+	if fs[0].Wanted == nil {
+		return map[string]bool{}, nil
 	}
+
+	return fs[0].Wanted, nil
 }
 
-// parseLinkInfo parses LinkInfo structures from a slice of generic netlink
+// parseFeatures parses Features structures from a slice of generic netlink
 // messages.
-func parseLinkInfo(msgs []genetlink.Message) ([]*LinkInfo, error) {
-	lis := make([]*LinkInfo, 0, len(msgs))
+func parseFeatures(msgs []genetlink.Message) ([]*Features, error) {
+	fs := make([]*Features, 0, len(msgs))
 	for _, m := range msgs {
 		ad, err := netlink.NewAttributeDecoder(m.Data)
 		if err != nil {
 			return nil, err
 		}
 
-		var li LinkInfo
+		var f Features
 		for ad.Next() {
 			switch ad.Type() {
-			case unix.ETHTOOL_A_LINKINFO_HEADER:
-				ad.Nested(parseInterface(&li.Interface))
-			case unix.ETHTOOL_A_LINKINFO_PORT:
-				li.Port = Port(ad.Uint8())
+			case unix.ETHTOOL_A_FEATURES_HEADER:
+				ad.Nested(parseInterface(&f.Interface))
+			case unix.ETHTOOL_A_FEATURES_HW:
+				ad.Nested(parseNamedBitset(&f.Hardware))
+			case unix.ETHTOOL_A_FEATURES_WANTED:
+				ad.Nested(parseNamedBitset(&f.Wanted))
+			case unix.ETHTOOL_A_FEATURES_ACTIVE:
+				ad.Nested(parseNamedBitset(&f.Active))
+			case unix.ETHTOOL_A_FEATURES_NOCHANGE:
+				ad.Nested(parseNamedBitset(&f.NoChange))
 			}
 		}
 
@@ -705,108 +742,129 @@ func parseLinkInfo(msgs []genetlink.Message) ([]*LinkInfo, error) {
 			return nil, err
 		}
 
-		lis = append(lis, &li)
+		fs = append(fs, &f)
 	}
 
-	return lis, nil
+	return fs, nil
 }
 
-// parseLinkModes parses LinkMode structures from a slice of generic netlink
-// messages.
-func parseLinkModes(msgs []genetlink.Message) ([]*LinkMode, error) {
-	lms := make([]*LinkMode, 0, len(msgs))
-	for _, m := range msgs {
-		ad, err := netlink.NewAttributeDecoder(m.Data)
-		if err != nil {
-			return nil, err
-		}
-
-		var lm LinkMode
-		for ad.Next() {
-			switch ad.Type() {
-			case unix.ETHTOOL_A_LINKMODES_HEADER:
-				ad.Nested(parseInterface(&lm.Interface))
-			case unix.ETHTOOL_A_LINKMODES_OURS:
-				ad.Nested(parseAdvertisedLinkModes(&lm.Ours))
-			case unix.ETHTOOL_A_LINKMODES_PEER:
-				ad.Nested(parseAdvertisedLinkModes(&lm.Peer))
-			case unix.ETHTOOL_A_LINKMODES_SPEED:
-				lm.SpeedMegabits = int(ad.Uint32())
-			case unix.ETHTOOL_A_LINKMODES_DUPLEX:
-				lm.Duplex = Duplex(ad.Uint8())
-			case unix.ETHTOOL_A_LINKMODES_AUTONEG:
-				lm.Autoneg = Autoneg(ad.Uint8())
-			}
-		}
+// AllRings fetches ring parameters for all ethtool-supported links.
+func (c *client) AllRings() ([]*Rings, error) {
+	return c.rings(netlink.Dump, Interface{})
+}
 
-		if err := ad.Err(); err != nil {
-			return nil, err
-		}
+// Rings fetches ring parameters for a single ethtool-supported link.
+func (c *client) Rings(ifi Interface) (*Rings, error) {
+	rs, err := c.rings(0, ifi)
+	if err != nil {
+		return nil, err
+	}
 
-		lms = append(lms, &lm)
+	if l := len(rs); l != 1 {
+		panicf("ethtool: unexpected number of Rings messages for request index: %d, name: %q: %d",
+			ifi.Index, ifi.Name, l)
 	}
 
-	return lms, nil
+	return rs[0], nil
 }
 
-// parseAdvertisedLinkModes decodes an ethtool compact bitset into the input
-// slice of AdvertisedLinkModes.
-func parseAdvertisedLinkModes(alms *[]AdvertisedLinkMode) func(*netlink.AttributeDecoder) error {
-	return func(ad *netlink.AttributeDecoder) error {
-		values, err := newBitset(ad)
-		if err != nil {
-			return err
-		}
+// rings is the shared logic for Client.(All)Rings.
+func (c *client) rings(flags netlink.HeaderFlags, ifi Interface) ([]*Rings, error) {
+	msgs, err := c.get(
+		unix.ETHTOOL_A_RINGS_HEADER,
+		unix.ETHTOOL_MSG_RINGS_GET,
+		flags,
+		ifi,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
 
-		for i, v := range values {
-			if v == 0 {
-				// No bits set, don't bother checking.
-				continue
-			}
+	return parseRings(msgs)
+}
 
-			// Test each bit to find which ones are set, and use that to look up
-			// the proper index in linkModes (accounting for the offset of 32
-			// for each value in the array) so we can find the correct link mode
-			// to attach. Note that the lookup assumes that there will never be
-			// any skipped bits in the linkModes table.
-			//
-			// Thanks 0x0f10, c_h_lunde, TheCi, and Wacholderbaer from Twitch
-			// chat for saving me from myself!
-			for j := 0; j < 32; j++ {
-				if v&(1<<j) != 0 {
-					m := linkModes[(32*i)+j]
-					*alms = append(*alms, AdvertisedLinkMode{
-						Index: int(m.bit),
-						Name:  m.str,
-					})
-				}
-			}
+// SetRings updates the given Interface with the non-nil ring parameters in
+// the RingsUpdate.
+func (c *client) SetRings(ifi Interface, ru *RingsUpdate) error {
+	_, err := c.get(
+		unix.ETHTOOL_A_RINGS_HEADER,
+		unix.ETHTOOL_MSG_RINGS_SET,
+		netlink.Acknowledge,
+		ifi,
+		ru.encode,
+	)
+	return err
+}
+
+// encode packs RingsUpdate data into the appropriate netlink attributes for
+// the encoder.
+func (ru *RingsUpdate) encode(ae *netlink.AttributeEncoder) {
+	u32 := func(typ uint16, v *int) {
+		if v != nil {
+			ae.Uint32(typ, uint32(*v))
 		}
+	}
 
-		return nil
+	u32(unix.ETHTOOL_A_RINGS_RX, ru.RX)
+	u32(unix.ETHTOOL_A_RINGS_RX_MINI, ru.RXMini)
+	u32(unix.ETHTOOL_A_RINGS_RX_JUMBO, ru.RXJumbo)
+	u32(unix.ETHTOOL_A_RINGS_TX, ru.TX)
+	u32(unix.ETHTOOL_A_RINGS_RX_BUF_LEN, ru.RXBufLen)
+	if ru.TCPDataSplit != nil {
+		ae.Uint8(unix.ETHTOOL_A_RINGS_TCP_DATA_SPLIT, uint8(*ru.TCPDataSplit))
 	}
+	u32(unix.ETHTOOL_A_RINGS_CQE_SIZE, ru.CQESize)
+	encodeBool(ae, unix.ETHTOOL_A_RINGS_TX_PUSH, ru.TXPush)
+	encodeBool(ae, unix.ETHTOOL_A_RINGS_RX_PUSH, ru.RXPush)
+	u32(unix.ETHTOOL_A_RINGS_TX_PUSH_BUF_LEN, ru.TXPushBufLen)
 }
 
-// parseLinkState parses LinkState structures from a slice of generic netlink
+// parseRings parses Rings structures from a slice of generic netlink
 // messages.
-func parseLinkState(msgs []genetlink.Message) ([]*LinkState, error) {
-	lss := make([]*LinkState, 0, len(msgs))
+func parseRings(msgs []genetlink.Message) ([]*Rings, error) {
+	rs := make([]*Rings, 0, len(msgs))
 	for _, m := range msgs {
 		ad, err := netlink.NewAttributeDecoder(m.Data)
 		if err != nil {
 			return nil, err
 		}
 
-		var ls LinkState
+		var r Rings
 		for ad.Next() {
-			// TODO(mdlayher): try this out on fancier NICs to parse more of the
-			// extended state information.
 			switch ad.Type() {
-			case unix.ETHTOOL_A_LINKSTATE_HEADER:
-				ad.Nested(parseInterface(&ls.Interface))
-			case unix.ETHTOOL_A_LINKSTATE_LINK:
-				// Up/down is reported as a uint8 boolean.
-				ls.Link = ad.Uint8() != 0
+			case unix.ETHTOOL_A_RINGS_HEADER:
+				ad.Nested(parseInterface(&r.Interface))
+			case unix.ETHTOOL_A_RINGS_RX_MAX:
+				r.RXMax = int(ad.Uint32())
+			case unix.ETHTOOL_A_RINGS_RX_MINI_MAX:
+				r.RXMiniMax = int(ad.Uint32())
+			case unix.ETHTOOL_A_RINGS_RX_JUMBO_MAX:
+				r.RXJumboMax = int(ad.Uint32())
+			case unix.ETHTOOL_A_RINGS_TX_MAX:
+				r.TXMax = int(ad.Uint32())
+			case unix.ETHTOOL_A_RINGS_RX:
+				r.RX = int(ad.Uint32())
+			case unix.ETHTOOL_A_RINGS_RX_MINI:
+				r.RXMini = int(ad.Uint32())
+			case unix.ETHTOOL_A_RINGS_RX_JUMBO:
+				r.RXJumbo = int(ad.Uint32())
+			case unix.ETHTOOL_A_RINGS_TX:
+				r.TX = int(ad.Uint32())
+			case unix.ETHTOOL_A_RINGS_RX_BUF_LEN:
+				r.RXBufLen = int(ad.Uint32())
+			case unix.ETHTOOL_A_RINGS_TCP_DATA_SPLIT:
+				r.TCPDataSplit = TCPDataSplit(ad.Uint8())
+			case unix.ETHTOOL_A_RINGS_CQE_SIZE:
+				r.CQESize = int(ad.Uint32())
+			case unix.ETHTOOL_A_RINGS_TX_PUSH:
+				r.TXPush = ad.Uint8() != 0
+			case unix.ETHTOOL_A_RINGS_RX_PUSH:
+				r.RXPush = ad.Uint8() != 0
+			case unix.ETHTOOL_A_RINGS_TX_PUSH_BUF_LEN:
+				r.TXPushBufLen = int(ad.Uint32())
+			case unix.ETHTOOL_A_RINGS_TX_PUSH_BUF_LEN_MAX:
+				r.TXPushBufLenMax = int(ad.Uint32())
 			}
 		}
 
@@ -814,7 +872,2867 @@ func parseLinkState(msgs []genetlink.Message) ([]*LinkState, error) {
 			return nil, err
 		}
 
-		lss = append(lss, &ls)
+		rs = append(rs, &r)
+	}
+
+	return rs, nil
+}
+
+// AllChannels fetches channel counts for all ethtool-supported links.
+func (c *client) AllChannels() ([]*Channels, error) {
+	return c.channels(netlink.Dump, Interface{})
+}
+
+// Channels fetches channel counts for a single ethtool-supported link.
+func (c *client) Channels(ifi Interface) (*Channels, error) {
+	chs, err := c.channels(0, ifi)
+	if err != nil {
+		return nil, err
+	}
+
+	if l := len(chs); l != 1 {
+		panicf("ethtool: unexpected number of Channels messages for request index: %d, name: %q: %d",
+			ifi.Index, ifi.Name, l)
+	}
+
+	return chs[0], nil
+}
+
+// channels is the shared logic for Client.(All)Channels.
+func (c *client) channels(flags netlink.HeaderFlags, ifi Interface) ([]*Channels, error) {
+	msgs, err := c.get(
+		unix.ETHTOOL_A_CHANNELS_HEADER,
+		unix.ETHTOOL_MSG_CHANNELS_GET,
+		flags,
+		ifi,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseChannels(msgs)
+}
+
+// SetChannels updates the given Interface with the non-nil channel counts in
+// the ChannelsUpdate.
+func (c *client) SetChannels(ifi Interface, cu *ChannelsUpdate) error {
+	_, err := c.get(
+		unix.ETHTOOL_A_CHANNELS_HEADER,
+		unix.ETHTOOL_MSG_CHANNELS_SET,
+		netlink.Acknowledge,
+		ifi,
+		cu.encode,
+	)
+
+	var eerr *Error
+	if !errors.As(err, &eerr) || !errors.Is(eerr.Err, unix.EINVAL) {
+		return err
+	}
+
+	// The kernel reports out of range counts as EINVAL, and older kernels
+	// or drivers may not explain why. Compare the request against the
+	// maximum counts to produce a more useful error if possible.
+	chs, cerr := c.Channels(ifi)
+	if cerr != nil {
+		return err
+	}
+
+	// Keep the kernel's extended acknowledgement message, if any, and only
+	// add the range information to the wrapped error.
+	if msg := cu.checkRange(chs); msg != "" {
+		eerr.Err = fmt.Errorf("ethtool: %s: %w", msg, eerr.Err)
+	}
+
+	return eerr
+}
+
+// encode packs ChannelsUpdate data into the appropriate netlink attributes
+// for the encoder.
+func (cu *ChannelsUpdate) encode(ae *netlink.AttributeEncoder) {
+	u32 := func(typ uint16, v *int) {
+		if v != nil {
+			ae.Uint32(typ, uint32(*v))
+		}
+	}
+
+	u32(unix.ETHTOOL_A_CHANNELS_RX_COUNT, cu.RX)
+	u32(unix.ETHTOOL_A_CHANNELS_TX_COUNT, cu.TX)
+	u32(unix.ETHTOOL_A_CHANNELS_OTHER_COUNT, cu.Other)
+	u32(unix.ETHTOOL_A_CHANNELS_COMBINED_COUNT, cu.Combined)
+}
+
+// checkRange returns a message describing the first requested count in the
+// ChannelsUpdate which exceeds the maximum in chs, or an empty string if all
+// of the counts are in range.
+func (cu *ChannelsUpdate) checkRange(chs *Channels) string {
+	for _, c := range []struct {
+		name  string
+		count *int
+		max   int
+	}{
+		{name: "RX", count: cu.RX, max: chs.RXMax},
+		{name: "TX", count: cu.TX, max: chs.TXMax},
+		{name: "other", count: cu.Other, max: chs.OtherMax},
+		{name: "combined", count: cu.Combined, max: chs.CombinedMax},
+	} {
+		if c.count != nil && (*c.count < 0 || *c.count > c.max) {
+			return fmt.Sprintf("requested %s channel count %d is out of range, maximum is %d",
+				c.name, *c.count, c.max)
+		}
+	}
+
+	return ""
+}
+
+// parseChannels parses Channels structures from a slice of generic netlink
+// messages.
+func parseChannels(msgs []genetlink.Message) ([]*Channels, error) {
+	chs := make([]*Channels, 0, len(msgs))
+	for _, m := range msgs {
+		ad, err := netlink.NewAttributeDecoder(m.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		var ch Channels
+		for ad.Next() {
+			switch ad.Type() {
+			case unix.ETHTOOL_A_CHANNELS_HEADER:
+				ad.Nested(parseInterface(&ch.Interface))
+			case unix.ETHTOOL_A_CHANNELS_RX_MAX:
+				ch.RXMax = int(ad.Uint32())
+			case unix.ETHTOOL_A_CHANNELS_TX_MAX:
+				ch.TXMax = int(ad.Uint32())
+			case unix.ETHTOOL_A_CHANNELS_OTHER_MAX:
+				ch.OtherMax = int(ad.Uint32())
+			case unix.ETHTOOL_A_CHANNELS_COMBINED_MAX:
+				ch.CombinedMax = int(ad.Uint32())
+			case unix.ETHTOOL_A_CHANNELS_RX_COUNT:
+				ch.RX = int(ad.Uint32())
+			case unix.ETHTOOL_A_CHANNELS_TX_COUNT:
+				ch.TX = int(ad.Uint32())
+			case unix.ETHTOOL_A_CHANNELS_OTHER_COUNT:
+				ch.Other = int(ad.Uint32())
+			case unix.ETHTOOL_A_CHANNELS_COMBINED_COUNT:
+				ch.Combined = int(ad.Uint32())
+			}
+		}
+
+		if err := ad.Err(); err != nil {
+			return nil, err
+		}
+
+		chs = append(chs, &ch)
+	}
+
+	return chs, nil
+}
+
+// AllCoalesce fetches interrupt coalescing parameters for all
+// ethtool-supported links.
+func (c *client) AllCoalesce() ([]*Coalesce, error) {
+	return c.coalesce(netlink.Dump, Interface{})
+}
+
+// Coalesce fetches interrupt coalescing parameters for a single
+// ethtool-supported link.
+func (c *client) Coalesce(ifi Interface) (*Coalesce, error) {
+	cs, err := c.coalesce(0, ifi)
+	if err != nil {
+		return nil, err
+	}
+
+	if l := len(cs); l != 1 {
+		panicf("ethtool: unexpected number of Coalesce messages for request index: %d, name: %q: %d",
+			ifi.Index, ifi.Name, l)
+	}
+
+	return cs[0], nil
+}
+
+// coalesce is the shared logic for Client.(All)Coalesce.
+func (c *client) coalesce(flags netlink.HeaderFlags, ifi Interface) ([]*Coalesce, error) {
+	msgs, err := c.get(
+		unix.ETHTOOL_A_COALESCE_HEADER,
+		unix.ETHTOOL_MSG_COALESCE_GET,
+		flags,
+		ifi,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCoalesce(msgs)
+}
+
+// SetCoalesce updates the given Interface with the non-nil interrupt
+// coalescing parameters in the CoalesceUpdate.
+func (c *client) SetCoalesce(ifi Interface, cu *CoalesceUpdate) error {
+	_, err := c.get(
+		unix.ETHTOOL_A_COALESCE_HEADER,
+		unix.ETHTOOL_MSG_COALESCE_SET,
+		netlink.Acknowledge,
+		ifi,
+		cu.encode,
+	)
+	return err
+}
+
+// encode packs CoalesceUpdate data into the appropriate netlink attributes
+// for the encoder.
+func (cu *CoalesceUpdate) encode(ae *netlink.AttributeEncoder) {
+	u32 := func(typ uint16, v *int) {
+		if v != nil {
+			ae.Uint32(typ, uint32(*v))
+		}
+	}
+
+	u32(unix.ETHTOOL_A_COALESCE_RX_USECS, cu.RXUsecs)
+	u32(unix.ETHTOOL_A_COALESCE_RX_MAX_FRAMES, cu.RXMaxFrames)
+	u32(unix.ETHTOOL_A_COALESCE_RX_USECS_IRQ, cu.RXUsecsIRQ)
+	u32(unix.ETHTOOL_A_COALESCE_RX_MAX_FRAMES_IRQ, cu.RXMaxFramesIRQ)
+	u32(unix.ETHTOOL_A_COALESCE_TX_USECS, cu.TXUsecs)
+	u32(unix.ETHTOOL_A_COALESCE_TX_MAX_FRAMES, cu.TXMaxFrames)
+	u32(unix.ETHTOOL_A_COALESCE_TX_USECS_IRQ, cu.TXUsecsIRQ)
+	u32(unix.ETHTOOL_A_COALESCE_TX_MAX_FRAMES_IRQ, cu.TXMaxFramesIRQ)
+	u32(unix.ETHTOOL_A_COALESCE_STATS_BLOCK_USECS, cu.StatsBlockUsecs)
+	encodeBool(ae, unix.ETHTOOL_A_COALESCE_USE_ADAPTIVE_RX, cu.AdaptiveRX)
+	encodeBool(ae, unix.ETHTOOL_A_COALESCE_USE_ADAPTIVE_TX, cu.AdaptiveTX)
+	u32(unix.ETHTOOL_A_COALESCE_PKT_RATE_LOW, cu.PacketRateLow)
+	u32(unix.ETHTOOL_A_COALESCE_RX_USECS_LOW, cu.RXUsecsLow)
+	u32(unix.ETHTOOL_A_COALESCE_RX_MAX_FRAMES_LOW, cu.RXMaxFramesLow)
+	u32(unix.ETHTOOL_A_COALESCE_TX_USECS_LOW, cu.TXUsecsLow)
+	u32(unix.ETHTOOL_A_COALESCE_TX_MAX_FRAMES_LOW, cu.TXMaxFramesLow)
+	u32(unix.ETHTOOL_A_COALESCE_PKT_RATE_HIGH, cu.PacketRateHigh)
+	u32(unix.ETHTOOL_A_COALESCE_RX_USECS_HIGH, cu.RXUsecsHigh)
+	u32(unix.ETHTOOL_A_COALESCE_RX_MAX_FRAMES_HIGH, cu.RXMaxFramesHigh)
+	u32(unix.ETHTOOL_A_COALESCE_TX_USECS_HIGH, cu.TXUsecsHigh)
+	u32(unix.ETHTOOL_A_COALESCE_TX_MAX_FRAMES_HIGH, cu.TXMaxFramesHigh)
+	u32(unix.ETHTOOL_A_COALESCE_RATE_SAMPLE_INTERVAL, cu.RateSampleIntervalSeconds)
+	encodeBool(ae, unix.ETHTOOL_A_COALESCE_USE_CQE_MODE_TX, cu.CQEModeTX)
+	encodeBool(ae, unix.ETHTOOL_A_COALESCE_USE_CQE_MODE_RX, cu.CQEModeRX)
+	u32(_ETHTOOL_A_COALESCE_TX_AGGR_MAX_BYTES, cu.TXAggrMaxBytes)
+	u32(_ETHTOOL_A_COALESCE_TX_AGGR_MAX_FRAMES, cu.TXAggrMaxFrames)
+	u32(_ETHTOOL_A_COALESCE_TX_AGGR_TIME_USECS, cu.TXAggrTimeUsecs)
+}
+
+// TODO: get these into x/sys/unix
+const (
+	_ETHTOOL_A_COALESCE_TX_AGGR_MAX_BYTES  = 0x1a //nolint:revive
+	_ETHTOOL_A_COALESCE_TX_AGGR_MAX_FRAMES = 0x1b //nolint:revive
+	_ETHTOOL_A_COALESCE_TX_AGGR_TIME_USECS = 0x1c //nolint:revive
+)
+
+// parseCoalesce parses Coalesce structures from a slice of generic netlink
+// messages.
+func parseCoalesce(msgs []genetlink.Message) ([]*Coalesce, error) {
+	cs := make([]*Coalesce, 0, len(msgs))
+	for _, m := range msgs {
+		ad, err := netlink.NewAttributeDecoder(m.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		var c Coalesce
+		for ad.Next() {
+			switch ad.Type() {
+			case unix.ETHTOOL_A_COALESCE_HEADER:
+				ad.Nested(parseInterface(&c.Interface))
+			case unix.ETHTOOL_A_COALESCE_RX_USECS:
+				c.RXUsecs = int(ad.Uint32())
+			case unix.ETHTOOL_A_COALESCE_RX_MAX_FRAMES:
+				c.RXMaxFrames = int(ad.Uint32())
+			case unix.ETHTOOL_A_COALESCE_RX_USECS_IRQ:
+				c.RXUsecsIRQ = int(ad.Uint32())
+			case unix.ETHTOOL_A_COALESCE_RX_MAX_FRAMES_IRQ:
+				c.RXMaxFramesIRQ = int(ad.Uint32())
+			case unix.ETHTOOL_A_COALESCE_TX_USECS:
+				c.TXUsecs = int(ad.Uint32())
+			case unix.ETHTOOL_A_COALESCE_TX_MAX_FRAMES:
+				c.TXMaxFrames = int(ad.Uint32())
+			case unix.ETHTOOL_A_COALESCE_TX_USECS_IRQ:
+				c.TXUsecsIRQ = int(ad.Uint32())
+			case unix.ETHTOOL_A_COALESCE_TX_MAX_FRAMES_IRQ:
+				c.TXMaxFramesIRQ = int(ad.Uint32())
+			case unix.ETHTOOL_A_COALESCE_STATS_BLOCK_USECS:
+				c.StatsBlockUsecs = int(ad.Uint32())
+			case unix.ETHTOOL_A_COALESCE_USE_ADAPTIVE_RX:
+				c.AdaptiveRX = ad.Uint8() != 0
+			case unix.ETHTOOL_A_COALESCE_USE_ADAPTIVE_TX:
+				c.AdaptiveTX = ad.Uint8() != 0
+			case unix.ETHTOOL_A_COALESCE_PKT_RATE_LOW:
+				c.PacketRateLow = int(ad.Uint32())
+			case unix.ETHTOOL_A_COALESCE_RX_USECS_LOW:
+				c.RXUsecsLow = int(ad.Uint32())
+			case unix.ETHTOOL_A_COALESCE_RX_MAX_FRAMES_LOW:
+				c.RXMaxFramesLow = int(ad.Uint32())
+			case unix.ETHTOOL_A_COALESCE_TX_USECS_LOW:
+				c.TXUsecsLow = int(ad.Uint32())
+			case unix.ETHTOOL_A_COALESCE_TX_MAX_FRAMES_LOW:
+				c.TXMaxFramesLow = int(ad.Uint32())
+			case unix.ETHTOOL_A_COALESCE_PKT_RATE_HIGH:
+				c.PacketRateHigh = int(ad.Uint32())
+			case unix.ETHTOOL_A_COALESCE_RX_USECS_HIGH:
+				c.RXUsecsHigh = int(ad.Uint32())
+			case unix.ETHTOOL_A_COALESCE_RX_MAX_FRAMES_HIGH:
+				c.RXMaxFramesHigh = int(ad.Uint32())
+			case unix.ETHTOOL_A_COALESCE_TX_USECS_HIGH:
+				c.TXUsecsHigh = int(ad.Uint32())
+			case unix.ETHTOOL_A_COALESCE_TX_MAX_FRAMES_HIGH:
+				c.TXMaxFramesHigh = int(ad.Uint32())
+			case unix.ETHTOOL_A_COALESCE_RATE_SAMPLE_INTERVAL:
+				c.RateSampleIntervalSeconds = int(ad.Uint32())
+			case unix.ETHTOOL_A_COALESCE_USE_CQE_MODE_TX:
+				c.CQEModeTX = ad.Uint8() != 0
+			case unix.ETHTOOL_A_COALESCE_USE_CQE_MODE_RX:
+				c.CQEModeRX = ad.Uint8() != 0
+			case _ETHTOOL_A_COALESCE_TX_AGGR_MAX_BYTES:
+				c.TXAggrMaxBytes = int(ad.Uint32())
+			case _ETHTOOL_A_COALESCE_TX_AGGR_MAX_FRAMES:
+				c.TXAggrMaxFrames = int(ad.Uint32())
+			case _ETHTOOL_A_COALESCE_TX_AGGR_TIME_USECS:
+				c.TXAggrTimeUsecs = int(ad.Uint32())
+			}
+		}
+
+		if err := ad.Err(); err != nil {
+			return nil, err
+		}
+
+		cs = append(cs, &c)
+	}
+
+	return cs, nil
+}
+
+// AllPause fetches pause frame settings for all ethtool-supported links.
+func (c *client) AllPause() ([]*Pause, error) {
+	return c.pause(netlink.Dump, 0, Interface{})
+}
+
+// Pause fetches pause frame settings for a single ethtool-supported link.
+func (c *client) Pause(ifi Interface) (*Pause, error) {
+	return c.pauseOne(0, ifi)
+}
+
+// PauseWithStats fetches pause frame settings and statistics for a single
+// ethtool-supported link.
+func (c *client) PauseWithStats(ifi Interface) (*Pause, error) {
+	return c.pauseOne(unix.ETHTOOL_FLAG_STATS, ifi)
+}
+
+// pauseOne is the shared logic for Client.Pause(WithStats).
+func (c *client) pauseOne(hflags uint32, ifi Interface) (*Pause, error) {
+	ps, err := c.pause(0, hflags, ifi)
+	if err != nil {
+		return nil, err
+	}
+
+	if l := len(ps); l != 1 {
+		panicf("ethtool: unexpected number of Pause messages for request index: %d, name: %q: %d",
+			ifi.Index, ifi.Name, l)
+	}
+
+	return ps[0], nil
+}
+
+// pause is the shared logic for Client.(All)Pause.
+func (c *client) pause(flags netlink.HeaderFlags, hflags uint32, ifi Interface) ([]*Pause, error) {
+	msgs, err := c.getFlags(
+		unix.ETHTOOL_A_PAUSE_HEADER,
+		unix.ETHTOOL_MSG_PAUSE_GET,
+		flags,
+		hflags,
+		ifi,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePause(msgs)
+}
+
+// SetPause updates the given Interface with the non-nil pause frame settings
+// in the PauseUpdate.
+func (c *client) SetPause(ifi Interface, pu *PauseUpdate) error {
+	_, err := c.get(
+		unix.ETHTOOL_A_PAUSE_HEADER,
+		unix.ETHTOOL_MSG_PAUSE_SET,
+		netlink.Acknowledge,
+		ifi,
+		pu.encode,
+	)
+	return err
+}
+
+// encode packs PauseUpdate data into the appropriate netlink attributes for
+// the encoder.
+func (pu *PauseUpdate) encode(ae *netlink.AttributeEncoder) {
+	encodeBool(ae, unix.ETHTOOL_A_PAUSE_AUTONEG, pu.Autoneg)
+	encodeBool(ae, unix.ETHTOOL_A_PAUSE_RX, pu.RX)
+	encodeBool(ae, unix.ETHTOOL_A_PAUSE_TX, pu.TX)
+}
+
+// parsePause parses Pause structures from a slice of generic netlink
+// messages.
+func parsePause(msgs []genetlink.Message) ([]*Pause, error) {
+	ps := make([]*Pause, 0, len(msgs))
+	for _, m := range msgs {
+		ad, err := netlink.NewAttributeDecoder(m.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		var p Pause
+		for ad.Next() {
+			switch ad.Type() {
+			case unix.ETHTOOL_A_PAUSE_HEADER:
+				ad.Nested(parseInterface(&p.Interface))
+			case unix.ETHTOOL_A_PAUSE_AUTONEG:
+				p.Autoneg = ad.Uint8() != 0
+			case unix.ETHTOOL_A_PAUSE_RX:
+				p.RX = ad.Uint8() != 0
+			case unix.ETHTOOL_A_PAUSE_TX:
+				p.TX = ad.Uint8() != 0
+			case unix.ETHTOOL_A_PAUSE_STATS:
+				p.Stats = new(PauseStats)
+				ad.Nested(parsePauseStats(p.Stats))
+			}
+		}
+
+		if err := ad.Err(); err != nil {
+			return nil, err
+		}
+
+		ps = append(ps, &p)
+	}
+
+	return ps, nil
+}
+
+// parsePauseStats parses pause frame statistics into a PauseStats structure.
+func parsePauseStats(ps *PauseStats) func(*netlink.AttributeDecoder) error {
+	return func(ad *netlink.AttributeDecoder) error {
+		for ad.Next() {
+			switch ad.Type() {
+			case unix.ETHTOOL_A_PAUSE_STAT_TX_FRAMES:
+				ps.TXFrames = ad.Uint64()
+			case unix.ETHTOOL_A_PAUSE_STAT_RX_FRAMES:
+				ps.RXFrames = ad.Uint64()
+			}
+		}
+
+		return ad.Err()
+	}
+}
+
+// AllEEE fetches Energy Efficient Ethernet settings for all
+// ethtool-supported links.
+func (c *client) AllEEE() ([]*EEE, error) {
+	return c.eee(netlink.Dump, Interface{})
+}
+
+// EEE fetches Energy Efficient Ethernet settings for a single
+// ethtool-supported link.
+func (c *client) EEE(ifi Interface) (*EEE, error) {
+	es, err := c.eee(0, ifi)
+	if err != nil {
+		return nil, err
+	}
+
+	if l := len(es); l != 1 {
+		panicf("ethtool: unexpected number of EEE messages for request index: %d, name: %q: %d",
+			ifi.Index, ifi.Name, l)
+	}
+
+	return es[0], nil
+}
+
+// eee is the shared logic for Client.(All)EEE.
+func (c *client) eee(flags netlink.HeaderFlags, ifi Interface) ([]*EEE, error) {
+	msgs, err := c.get(
+		unix.ETHTOOL_A_EEE_HEADER,
+		unix.ETHTOOL_MSG_EEE_GET,
+		flags,
+		ifi,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseEEE(msgs)
+}
+
+// SetEEE updates the given Interface with the non-nil Energy Efficient
+// Ethernet settings in the EEEUpdate.
+func (c *client) SetEEE(ifi Interface, eu *EEEUpdate) error {
+	if eu.Advertise != nil && eu.Advertise.Sign() < 0 {
+		return errors.New("ethtool: can't update EEE, Advertise is invalid")
+	}
+	_, err := c.get(
+		unix.ETHTOOL_A_EEE_HEADER,
+		unix.ETHTOOL_MSG_EEE_SET,
+		netlink.Acknowledge,
+		ifi,
+		eu.encode,
+	)
+	return err
+}
+
+// encode packs EEEUpdate data into the appropriate netlink attributes for the
+// encoder.
+func (eu *EEEUpdate) encode(ae *netlink.AttributeEncoder) {
+	if eu.Advertise != nil {
+		encodeLinkModes(ae, unix.ETHTOOL_A_EEE_MODES_OURS, eu.Advertise)
+	}
+	encodeBool(ae, unix.ETHTOOL_A_EEE_ENABLED, eu.Enabled)
+	encodeBool(ae, unix.ETHTOOL_A_EEE_TX_LPI_ENABLED, eu.TXLPIEnabled)
+	if eu.TXLPITimerUsecs != nil {
+		ae.Uint32(unix.ETHTOOL_A_EEE_TX_LPI_TIMER, uint32(*eu.TXLPITimerUsecs))
+	}
+}
+
+// parseEEE parses EEE structures from a slice of generic netlink messages.
+func parseEEE(msgs []genetlink.Message) ([]*EEE, error) {
+	es := make([]*EEE, 0, len(msgs))
+	for _, m := range msgs {
+		ad, err := netlink.NewAttributeDecoder(m.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		var e EEE
+		for ad.Next() {
+			switch ad.Type() {
+			case unix.ETHTOOL_A_EEE_HEADER:
+				ad.Nested(parseInterface(&e.Interface))
+			case unix.ETHTOOL_A_EEE_MODES_OURS:
+				ad.Nested(parseAdvertisedLinkModes(&e.Ours))
+			case unix.ETHTOOL_A_EEE_MODES_PEER:
+				ad.Nested(parseAdvertisedLinkModes(&e.Peer))
+			case unix.ETHTOOL_A_EEE_ACTIVE:
+				e.Active = ad.Uint8() != 0
+			case unix.ETHTOOL_A_EEE_ENABLED:
+				e.Enabled = ad.Uint8() != 0
+			case unix.ETHTOOL_A_EEE_TX_LPI_ENABLED:
+				e.TXLPIEnabled = ad.Uint8() != 0
+			case unix.ETHTOOL_A_EEE_TX_LPI_TIMER:
+				e.TXLPITimerUsecs = int(ad.Uint32())
+			}
+		}
+
+		if err := ad.Err(); err != nil {
+			return nil, err
+		}
+
+		es = append(es, &e)
+	}
+
+	return es, nil
+}
+
+// AllTimestampInfo fetches timestamping capabilities for all
+// ethtool-supported links.
+func (c *client) AllTimestampInfo() ([]*TimestampInfo, error) {
+	return c.timestampInfo(netlink.Dump, 0, Interface{})
+}
+
+// TimestampInfo fetches timestamping capabilities for a single
+// ethtool-supported link.
+func (c *client) TimestampInfo(ifi Interface) (*TimestampInfo, error) {
+	return c.timestampInfoOne(0, ifi)
+}
+
+// TimestampInfoWithStats fetches timestamping capabilities and statistics for
+// a single ethtool-supported link.
+func (c *client) TimestampInfoWithStats(ifi Interface) (*TimestampInfo, error) {
+	return c.timestampInfoOne(unix.ETHTOOL_FLAG_STATS, ifi)
+}
+
+// timestampInfoOne is the shared logic for Client.TimestampInfo(WithStats).
+func (c *client) timestampInfoOne(hflags uint32, ifi Interface) (*TimestampInfo, error) {
+	tis, err := c.timestampInfo(0, hflags, ifi)
+	if err != nil {
+		return nil, err
+	}
+
+	if l := len(tis); l != 1 {
+		panicf("ethtool: unexpected number of TimestampInfo messages for request index: %d, name: %q: %d",
+			ifi.Index, ifi.Name, l)
+	}
+
+	return tis[0], nil
+}
+
+// timestampInfo is the shared logic for Client.(All)TimestampInfo.
+func (c *client) timestampInfo(flags netlink.HeaderFlags, hflags uint32, ifi Interface) ([]*TimestampInfo, error) {
+	msgs, err := c.getFlags(
+		unix.ETHTOOL_A_TSINFO_HEADER,
+		unix.ETHTOOL_MSG_TSINFO_GET,
+		flags,
+		hflags,
+		ifi,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseTimestampInfo(msgs)
+}
+
+// parseTimestampInfo parses TimestampInfo structures from a slice of generic
+// netlink messages.
+func parseTimestampInfo(msgs []genetlink.Message) ([]*TimestampInfo, error) {
+	tis := make([]*TimestampInfo, 0, len(msgs))
+	for _, m := range msgs {
+		ad, err := netlink.NewAttributeDecoder(m.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		// The PHC index is omitted when the device has no PHC.
+		ti := TimestampInfo{PHCIndex: -1}
+		for ad.Next() {
+			switch ad.Type() {
+			case unix.ETHTOOL_A_TSINFO_HEADER:
+				ad.Nested(parseInterface(&ti.Interface))
+			case unix.ETHTOOL_A_TSINFO_TIMESTAMPING:
+				ad.Nested(parseNamedBitset(&ti.Timestamping))
+			case unix.ETHTOOL_A_TSINFO_TX_TYPES:
+				ad.Nested(parseNamedBitset(&ti.TXTypes))
+			case unix.ETHTOOL_A_TSINFO_RX_FILTERS:
+				ad.Nested(parseNamedBitset(&ti.RXFilters))
+			case unix.ETHTOOL_A_TSINFO_PHC_INDEX:
+				ti.PHCIndex = int(ad.Uint32())
+			case unix.ETHTOOL_A_TSINFO_STATS:
+				ti.Stats = new(TimestampStats)
+				ad.Nested(parseTimestampStats(ti.Stats))
+			}
+		}
+
+		if err := ad.Err(); err != nil {
+			return nil, err
+		}
+
+		tis = append(tis, &ti)
+	}
+
+	return tis, nil
+}
+
+// parseTimestampStats parses hardware timestamping statistics into a
+// TimestampStats structure.
+func parseTimestampStats(ts *TimestampStats) func(*netlink.AttributeDecoder) error {
+	return func(ad *netlink.AttributeDecoder) error {
+		for ad.Next() {
+			switch ad.Type() {
+			case _ETHTOOL_A_TS_STAT_TX_PKTS:
+				decodeUint(ad, &ts.TXPackets)
+			case _ETHTOOL_A_TS_STAT_TX_LOST:
+				decodeUint(ad, &ts.TXLost)
+			case _ETHTOOL_A_TS_STAT_TX_ERR:
+				decodeUint(ad, &ts.TXErrors)
+			}
+		}
+
+		return ad.Err()
+	}
+}
+
+// TODO: get these into x/sys/unix
+const (
+	_ETHTOOL_A_TS_STAT_UNSPEC  = iota //nolint:revive
+	_ETHTOOL_A_TS_STAT_TX_PKTS        //nolint:revive
+	_ETHTOOL_A_TS_STAT_TX_LOST        //nolint:revive
+	_ETHTOOL_A_TS_STAT_TX_ERR         //nolint:revive
+)
+
+// AllPHCVClocks fetches PTP hardware clock virtual clocks for all
+// ethtool-supported links.
+func (c *client) AllPHCVClocks() ([]*PHCVClocks, error) {
+	return c.phcVClocks(netlink.Dump, Interface{})
+}
+
+// PHCVClocks fetches PTP hardware clock virtual clocks for a single
+// ethtool-supported link.
+func (c *client) PHCVClocks(ifi Interface) (*PHCVClocks, error) {
+	vcs, err := c.phcVClocks(0, ifi)
+	if err != nil {
+		return nil, err
+	}
+
+	if l := len(vcs); l != 1 {
+		panicf("ethtool: unexpected number of PHCVClocks messages for request index: %d, name: %q: %d",
+			ifi.Index, ifi.Name, l)
+	}
+
+	return vcs[0], nil
+}
+
+// phcVClocks is the shared logic for Client.(All)PHCVClocks.
+func (c *client) phcVClocks(flags netlink.HeaderFlags, ifi Interface) ([]*PHCVClocks, error) {
+	msgs, err := c.get(
+		_ETHTOOL_A_PHC_VCLOCKS_HEADER,
+		unix.ETHTOOL_MSG_PHC_VCLOCKS_GET,
+		flags,
+		ifi,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePHCVClocks(msgs)
+}
+
+// TODO: get these into x/sys/unix
+const (
+	_ETHTOOL_A_PHC_VCLOCKS_UNSPEC = iota //nolint:revive
+	_ETHTOOL_A_PHC_VCLOCKS_HEADER        //nolint:revive
+	_ETHTOOL_A_PHC_VCLOCKS_NUM           //nolint:revive
+	_ETHTOOL_A_PHC_VCLOCKS_INDEX         //nolint:revive
+)
+
+// parsePHCVClocks parses PHCVClocks structures from a slice of generic netlink
+// messages.
+func parsePHCVClocks(msgs []genetlink.Message) ([]*PHCVClocks, error) {
+	vcs := make([]*PHCVClocks, 0, len(msgs))
+	for _, m := range msgs {
+		ad, err := netlink.NewAttributeDecoder(m.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			vc  PHCVClocks
+			num = -1
+		)
+		for ad.Next() {
+			switch ad.Type() {
+			case _ETHTOOL_A_PHC_VCLOCKS_HEADER:
+				ad.Nested(parseInterface(&vc.Interface))
+			case _ETHTOOL_A_PHC_VCLOCKS_NUM:
+				num = int(ad.Uint32())
+			case _ETHTOOL_A_PHC_VCLOCKS_INDEX:
+				// The indices are a packed array of native endian int32s.
+				ad.Do(func(b []byte) error {
+					if len(b)%4 != 0 {
+						return fmt.Errorf("ethtool: invalid PHC virtual clock index array length: %d", len(b))
+					}
+
+					for i := 0; i < len(b); i += 4 {
+						vc.Indices = append(vc.Indices, int(int32(binary.NativeEndian.Uint32(b[i:i+4]))))
+					}
+					return nil
+				})
+			}
+		}
+
+		if err := ad.Err(); err != nil {
+			return nil, err
+		}
+
+		// The kernel omits both attributes when there are no virtual clocks.
+		if num != -1 && num != len(vc.Indices) {
+			return nil, fmt.Errorf("ethtool: PHC virtual clock count %d does not match number of indices %d",
+				num, len(vc.Indices))
+		}
+
+		vcs = append(vcs, &vc)
+	}
+
+	return vcs, nil
+}
+
+// decodeUint decodes a variable width unsigned integer attribute into v, which
+// the kernel packs as either 32 or 64 bits depending on its value.
+func decodeUint(ad *netlink.AttributeDecoder, v *uint64) {
+	ad.Do(func(b []byte) error {
+		switch len(b) {
+		case 4:
+			*v = uint64(binary.NativeEndian.Uint32(b))
+		case 8:
+			*v = binary.NativeEndian.Uint64(b)
+		default:
+			return fmt.Errorf("ethtool: invalid uint attribute length: %d", len(b))
+		}
+
+		return nil
+	})
+}
+
+// AllTunnelInfo fetches UDP tunnel offload tables for all ethtool-supported
+// links.
+func (c *client) AllTunnelInfo() ([]*TunnelInfo, error) {
+	tis, err := c.tunnelInfo(netlink.Dump, Interface{})
+	if err == nil || !errors.Is(err, unix.EOPNOTSUPP) {
+		return tis, err
+	}
+
+	// The kernel skips links without tunnel offload info when dumping, but
+	// still fails the entire dump if the last link it visits is one of them.
+	// Fall back to querying each link individually.
+	return eachLink(c, c.TunnelInfo)
+}
+
+// eachLink calls fn for each ethtool-supported link and returns the results,
+// skipping links for which fn is not supported.
+func eachLink[T any](c *client, fn func(Interface) (T, error)) ([]T, error) {
+	lis, err := c.LinkInfos()
+	if err != nil {
+		return nil, err
+	}
+
+	ts := make([]T, 0, len(lis))
+	for _, li := range lis {
+		t, err := fn(li.Interface)
+		if err != nil {
+			if errors.Is(err, unix.EOPNOTSUPP) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		ts = append(ts, t)
+	}
+
+	return ts, nil
+}
+
+// TunnelInfo fetches UDP tunnel offload tables for a single ethtool-supported
+// link.
+func (c *client) TunnelInfo(ifi Interface) (*TunnelInfo, error) {
+	tis, err := c.tunnelInfo(0, ifi)
+	if err != nil {
+		return nil, err
+	}
+
+	if l := len(tis); l != 1 {
+		panicf("ethtool: unexpected number of TunnelInfo messages for request index: %d, name: %q: %d",
+			ifi.Index, ifi.Name, l)
+	}
+
+	return tis[0], nil
+}
+
+// tunnelInfo is the shared logic for Client.(All)TunnelInfo.
+func (c *client) tunnelInfo(flags netlink.HeaderFlags, ifi Interface) ([]*TunnelInfo, error) {
+	msgs, err := c.get(
+		unix.ETHTOOL_A_TUNNEL_INFO_HEADER,
+		unix.ETHTOOL_MSG_TUNNEL_INFO_GET,
+		flags,
+		ifi,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseTunnelInfo(msgs)
+}
+
+// parseTunnelInfo parses TunnelInfo structures from a slice of generic netlink
+// messages.
+func parseTunnelInfo(msgs []genetlink.Message) ([]*TunnelInfo, error) {
+	tis := make([]*TunnelInfo, 0, len(msgs))
+	for _, m := range msgs {
+		ad, err := netlink.NewAttributeDecoder(m.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		var ti TunnelInfo
+		for ad.Next() {
+			switch ad.Type() {
+			case unix.ETHTOOL_A_TUNNEL_INFO_HEADER:
+				ad.Nested(parseInterface(&ti.Interface))
+			case unix.ETHTOOL_A_TUNNEL_INFO_UDP_PORTS:
+				ad.Nested(func(nad *netlink.AttributeDecoder) error {
+					for nad.Next() {
+						if nad.Type() != unix.ETHTOOL_A_TUNNEL_UDP_TABLE {
+							continue
+						}
+
+						var t UDPTunnelTable
+						nad.Nested(parseUDPTunnelTable(&t))
+						ti.UDPTables = append(ti.UDPTables, t)
+					}
+					return nad.Err()
+				})
+			}
+		}
+
+		if err := ad.Err(); err != nil {
+			return nil, err
+		}
+
+		tis = append(tis, &ti)
+	}
+
+	return tis, nil
+}
+
+// parseUDPTunnelTable parses a single UDP tunnel port table into t.
+func parseUDPTunnelTable(t *UDPTunnelTable) func(*netlink.AttributeDecoder) error {
+	return func(ad *netlink.AttributeDecoder) error {
+		for ad.Next() {
+			switch ad.Type() {
+			case unix.ETHTOOL_A_TUNNEL_UDP_TABLE_SIZE:
+				t.Size = int(ad.Uint32())
+			case unix.ETHTOOL_A_TUNNEL_UDP_TABLE_TYPES:
+				ad.Nested(func(nad *netlink.AttributeDecoder) error {
+					types, err := newBitset(nad)
+					if err != nil {
+						return err
+					}
+
+					for i := 0; i < len(types)*32; i++ {
+						if types.test(i) {
+							t.Types = append(t.Types, UDPTunnelType(i))
+						}
+					}
+					return nil
+				})
+			case unix.ETHTOOL_A_TUNNEL_UDP_TABLE_ENTRY:
+				ad.Nested(func(nad *netlink.AttributeDecoder) error {
+					var p UDPTunnelPort
+					for nad.Next() {
+						switch nad.Type() {
+						case unix.ETHTOOL_A_TUNNEL_UDP_ENTRY_PORT:
+							// The port is in network byte order.
+							nad.Do(func(b []byte) error {
+								if len(b) != 2 {
+									return fmt.Errorf("ethtool: invalid UDP tunnel port length: %d", len(b))
+								}
+
+								p.Port = int(binary.BigEndian.Uint16(b))
+								return nil
+							})
+						case unix.ETHTOOL_A_TUNNEL_UDP_ENTRY_TYPE:
+							p.Type = UDPTunnelType(nad.Uint32())
+						}
+					}
+
+					t.Ports = append(t.Ports, p)
+					return nad.Err()
+				})
+			}
+		}
+
+		return nil
+	}
+}
+
+// AllModules fetches transceiver module settings for all ethtool-supported
+// links.
+func (c *client) AllModules() ([]*Module, error) {
+	return c.module(netlink.Dump, Interface{})
+}
+
+// Module fetches transceiver module settings for a single ethtool-supported
+// link.
+func (c *client) Module(ifi Interface) (*Module, error) {
+	ms, err := c.module(0, ifi)
+	if err != nil {
+		return nil, err
+	}
+
+	if l := len(ms); l != 1 {
+		panicf("ethtool: unexpected number of Module messages for request index: %d, name: %q: %d",
+			ifi.Index, ifi.Name, l)
+	}
+
+	return ms[0], nil
+}
+
+// module is the shared logic for Client.(All)Module(s).
+func (c *client) module(flags netlink.HeaderFlags, ifi Interface) ([]*Module, error) {
+	msgs, err := c.get(
+		_ETHTOOL_A_MODULE_HEADER,
+		unix.ETHTOOL_MSG_MODULE_GET,
+		flags,
+		ifi,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseModules(msgs)
+}
+
+// SetModule configures transceiver module settings for a single
+// ethtool-supported interface.
+func (c *client) SetModule(ifi Interface, mu *ModuleUpdate) error {
+	_, err := c.get(
+		_ETHTOOL_A_MODULE_HEADER,
+		unix.ETHTOOL_MSG_MODULE_SET,
+		netlink.Acknowledge,
+		ifi,
+		mu.encode,
+	)
+	return err
+}
+
+// encode packs ModuleUpdate data into the appropriate netlink attributes for
+// the encoder.
+func (mu *ModuleUpdate) encode(ae *netlink.AttributeEncoder) {
+	if mu.PowerModePolicy != nil {
+		ae.Uint8(_ETHTOOL_A_MODULE_POWER_MODE_POLICY, uint8(*mu.PowerModePolicy))
+	}
+}
+
+// TODO: get these into x/sys/unix
+const (
+	_ETHTOOL_A_MODULE_UNSPEC            = iota //nolint:revive
+	_ETHTOOL_A_MODULE_HEADER                   //nolint:revive
+	_ETHTOOL_A_MODULE_POWER_MODE_POLICY        //nolint:revive
+	_ETHTOOL_A_MODULE_POWER_MODE               //nolint:revive
+)
+
+// parseModules parses Module structures from a slice of generic netlink
+// messages.
+func parseModules(msgs []genetlink.Message) ([]*Module, error) {
+	ms := make([]*Module, 0, len(msgs))
+	for _, m := range msgs {
+		ad, err := netlink.NewAttributeDecoder(m.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		var mod Module
+		for ad.Next() {
+			switch ad.Type() {
+			case _ETHTOOL_A_MODULE_HEADER:
+				ad.Nested(parseInterface(&mod.Interface))
+			case _ETHTOOL_A_MODULE_POWER_MODE_POLICY:
+				mod.PowerModePolicy = ModulePowerModePolicy(ad.Uint8())
+			case _ETHTOOL_A_MODULE_POWER_MODE:
+				mod.PowerMode = ModulePowerMode(ad.Uint8())
+			}
+		}
+
+		if err := ad.Err(); err != nil {
+			return nil, err
+		}
+
+		ms = append(ms, &mod)
+	}
+
+	return ms, nil
+}
+
+// AllPSE fetches Power Sourcing Equipment state for all ethtool-supported
+// links which have a PSE.
+func (c *client) AllPSE() ([]*PSE, error) {
+	pses, err := c.pse(netlink.Dump, Interface{})
+	if err == nil || !errors.Is(err, unix.EOPNOTSUPP) {
+		return pses, err
+	}
+
+	// As with tunnel info, the dump fails if the last link has no PSE.
+	return eachLink(c, c.PSE)
+}
+
+// PSE fetches Power Sourcing Equipment state for a single ethtool-supported
+// link.
+func (c *client) PSE(ifi Interface) (*PSE, error) {
+	pses, err := c.pse(0, ifi)
+	if err != nil {
+		return nil, err
+	}
+
+	if l := len(pses); l != 1 {
+		panicf("ethtool: unexpected number of PSE messages for request index: %d, name: %q: %d",
+			ifi.Index, ifi.Name, l)
+	}
+
+	return pses[0], nil
+}
+
+// pse is the shared logic for Client.(All)PSE.
+func (c *client) pse(flags netlink.HeaderFlags, ifi Interface) ([]*PSE, error) {
+	msgs, err := c.get(
+		_ETHTOOL_A_PSE_HEADER,
+		unix.ETHTOOL_MSG_PSE_GET,
+		flags,
+		ifi,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePSE(msgs)
+}
+
+// SetPSE configures Power Sourcing Equipment settings for a single
+// ethtool-supported interface.
+func (c *client) SetPSE(ifi Interface, pu *PSEUpdate) error {
+	_, err := c.get(
+		_ETHTOOL_A_PSE_HEADER,
+		unix.ETHTOOL_MSG_PSE_SET,
+		netlink.Acknowledge,
+		ifi,
+		pu.encode,
+	)
+	return err
+}
+
+// encode packs PSEUpdate data into the appropriate netlink attributes for the
+// encoder.
+func (pu *PSEUpdate) encode(ae *netlink.AttributeEncoder) {
+	if pu.PoDLAdminControl != nil {
+		ae.Uint32(_ETHTOOL_A_PODL_PSE_ADMIN_CONTROL, uint32(*pu.PoDLAdminControl))
+	}
+	if pu.C33AdminControl != nil {
+		ae.Uint32(_ETHTOOL_A_C33_PSE_ADMIN_CONTROL, uint32(*pu.C33AdminControl))
+	}
+	if pu.C33PowerLimitMilliwatts != nil {
+		ae.Uint32(_ETHTOOL_A_C33_PSE_AVAIL_PW_LIMIT, uint32(*pu.C33PowerLimitMilliwatts))
+	}
+	if pu.Priority != nil {
+		ae.Uint32(_ETHTOOL_A_PSE_PRIO, uint32(*pu.Priority))
+	}
+}
+
+// TODO: get these into x/sys/unix
+const (
+	_ETHTOOL_A_PSE_UNSPEC              = iota //nolint:revive
+	_ETHTOOL_A_PSE_HEADER                     //nolint:revive
+	_ETHTOOL_A_PODL_PSE_ADMIN_STATE           //nolint:revive
+	_ETHTOOL_A_PODL_PSE_ADMIN_CONTROL         //nolint:revive
+	_ETHTOOL_A_PODL_PSE_PW_D_STATUS           //nolint:revive
+	_ETHTOOL_A_C33_PSE_ADMIN_STATE            //nolint:revive
+	_ETHTOOL_A_C33_PSE_ADMIN_CONTROL          //nolint:revive
+	_ETHTOOL_A_C33_PSE_PW_D_STATUS            //nolint:revive
+	_ETHTOOL_A_C33_PSE_PW_CLASS               //nolint:revive
+	_ETHTOOL_A_C33_PSE_ACTUAL_PW              //nolint:revive
+	_ETHTOOL_A_C33_PSE_EXT_STATE              //nolint:revive
+	_ETHTOOL_A_C33_PSE_EXT_SUBSTATE           //nolint:revive
+	_ETHTOOL_A_C33_PSE_AVAIL_PW_LIMIT         //nolint:revive
+	_ETHTOOL_A_C33_PSE_PW_LIMIT_RANGES        //nolint:revive
+	_ETHTOOL_A_PSE_PW_D_ID                    //nolint:revive
+	_ETHTOOL_A_PSE_PRIO_MAX                   //nolint:revive
+	_ETHTOOL_A_PSE_PRIO                       //nolint:revive
+)
+
+const (
+	_ETHTOOL_A_C33_PSE_PW_LIMIT_UNSPEC = iota //nolint:revive
+	_ETHTOOL_A_C33_PSE_PW_LIMIT_MIN           //nolint:revive
+	_ETHTOOL_A_C33_PSE_PW_LIMIT_MAX           //nolint:revive
+)
+
+// parsePSE parses PSE structures from a slice of generic netlink messages.
+func parsePSE(msgs []genetlink.Message) ([]*PSE, error) {
+	pses := make([]*PSE, 0, len(msgs))
+	for _, m := range msgs {
+		ad, err := netlink.NewAttributeDecoder(m.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			p    PSE
+			podl PoDLPSE
+			c33  C33PSE
+
+			hasPoDL, hasC33 bool
+		)
+
+		for ad.Next() {
+			switch ad.Type() {
+			case _ETHTOOL_A_PSE_HEADER:
+				ad.Nested(parseInterface(&p.Interface))
+			case _ETHTOOL_A_PODL_PSE_ADMIN_STATE:
+				hasPoDL = true
+				podl.AdminState = PSEAdminState(ad.Uint32())
+			case _ETHTOOL_A_PODL_PSE_PW_D_STATUS:
+				hasPoDL = true
+				podl.PowerStatus = PoDLPSEPowerStatus(ad.Uint32())
+			case _ETHTOOL_A_C33_PSE_ADMIN_STATE:
+				hasC33 = true
+				c33.AdminState = PSEAdminState(ad.Uint32())
+			case _ETHTOOL_A_C33_PSE_PW_D_STATUS:
+				hasC33 = true
+				c33.PowerStatus = C33PSEPowerStatus(ad.Uint32())
+			case _ETHTOOL_A_C33_PSE_PW_CLASS:
+				hasC33 = true
+				c33.PowerClass = int(ad.Uint32())
+			case _ETHTOOL_A_C33_PSE_ACTUAL_PW:
+				hasC33 = true
+				c33.ActualPowerMilliwatts = int(ad.Uint32())
+			case _ETHTOOL_A_C33_PSE_EXT_STATE:
+				hasC33 = true
+				c33.ExtState = C33PSEExtState(ad.Uint32())
+			case _ETHTOOL_A_C33_PSE_EXT_SUBSTATE:
+				hasC33 = true
+				c33.ExtSubstate = ad.Uint32()
+			case _ETHTOOL_A_C33_PSE_AVAIL_PW_LIMIT:
+				hasC33 = true
+				c33.PowerLimitMilliwatts = int(ad.Uint32())
+			case _ETHTOOL_A_C33_PSE_PW_LIMIT_RANGES:
+				hasC33 = true
+				ad.Nested(func(nad *netlink.AttributeDecoder) error {
+					var r PSEPowerLimitRange
+					for nad.Next() {
+						switch nad.Type() {
+						case _ETHTOOL_A_C33_PSE_PW_LIMIT_MIN:
+							r.MinMilliwatts = int(nad.Uint32())
+						case _ETHTOOL_A_C33_PSE_PW_LIMIT_MAX:
+							r.MaxMilliwatts = int(nad.Uint32())
+						}
+					}
+
+					c33.PowerLimitRanges = append(c33.PowerLimitRanges, r)
+					return nad.Err()
+				})
+			case _ETHTOOL_A_PSE_PW_D_ID:
+				p.PowerDomainID = int(ad.Uint32())
+			case _ETHTOOL_A_PSE_PRIO_MAX:
+				p.PriorityMax = int(ad.Uint32())
+			case _ETHTOOL_A_PSE_PRIO:
+				p.Priority = int(ad.Uint32())
+			}
+		}
+
+		if err := ad.Err(); err != nil {
+			return nil, err
+		}
+
+		if hasPoDL {
+			p.PoDL = &podl
+		}
+		if hasC33 {
+			p.C33 = &c33
+		}
+
+		pses = append(pses, &p)
+	}
+
+	return pses, nil
+}
+
+// RSS fetches the RSS configuration of a single RSS context on a single
+// ethtool-supported link.
+func (c *client) RSS(ifi Interface, context int) (*RSS, error) {
+	rss, err := c.rss(0, ifi, context)
+	if err != nil {
+		return nil, err
+	}
+
+	if l := len(rss); l != 1 {
+		panicf("ethtool: unexpected number of RSS messages for request index: %d, name: %q: %d",
+			ifi.Index, ifi.Name, l)
+	}
+
+	if rss[0].Context == 0 {
+		rss[0].Context = context
+	}
+
+	return rss[0], nil
+}
+
+// rss is the shared logic for fetching RSS contexts.
+func (c *client) rss(flags netlink.HeaderFlags, ifi Interface, context int) ([]*RSS, error) {
+	msgs, err := c.get(
+		_ETHTOOL_A_RSS_HEADER,
+		unix.ETHTOOL_MSG_RSS_GET,
+		flags,
+		ifi,
+		func(ae *netlink.AttributeEncoder) {
+			// Older kernels reject the context attribute, so only send it
+			// when a non-default context is requested.
+			if context != 0 {
+				ae.Uint32(_ETHTOOL_A_RSS_CONTEXT, uint32(context))
+			}
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rss, hfuncs, err := parseRSS(msgs)
+	if err != nil {
+		return nil, err
+	}
+
+	// The hash function is a bit in the RSS hash functions string set, so
+	// its name must be looked up separately.
+	if !slices.ContainsFunc(hfuncs, func(h uint32) bool { return h != 0 }) {
+		return rss, nil
+	}
+
+	names, err := c.rssHashFuncs()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, r := range rss {
+		for bit, name := range names {
+			if hfuncs[i]&(1<<bit) != 0 {
+				r.HashFunction = name
+				break
+			}
+		}
+	}
+
+	return rss, nil
+}
+
+// RSSContexts fetches the RSS configuration of every RSS context on a single
+// ethtool-supported link.
+func (c *client) RSSContexts(ifi Interface) ([]*RSS, error) {
+	// Dumping with a device in the header only returns that device's
+	// contexts.
+	return c.rss(netlink.Dump, ifi, 0)
+}
+
+// CreateRSSContext creates an RSS context on a single ethtool-supported
+// interface.
+func (c *client) CreateRSSContext(ifi Interface, ru *RSSUpdate) (int, error) {
+	if ru == nil {
+		ru = &RSSUpdate{}
+	}
+
+	hfunc, err := c.rssHashFunc(ru.HashFunction)
+	if err != nil {
+		return 0, err
+	}
+
+	msgs, err := c.get(
+		_ETHTOOL_A_RSS_HEADER,
+		_ETHTOOL_MSG_RSS_CREATE_ACT,
+		0,
+		ifi,
+		func(ae *netlink.AttributeEncoder) {
+			if hfunc != 0 {
+				ae.Uint32(_ETHTOOL_A_RSS_HFUNC, hfunc)
+			}
+			ru.encode(ae)
+		},
+	)
+	switch {
+	case errors.Is(err, unix.EOPNOTSUPP):
+		// The kernel predates RSS context creation over netlink.
+		return c.rxfhCreate(ifi, ru, hfunc)
+	case err != nil:
+		return 0, err
+	}
+
+	rss, _, err := parseRSS(msgs)
+	if err != nil {
+		return 0, err
+	}
+
+	if l := len(rss); l != 1 {
+		panicf("ethtool: unexpected number of RSS messages for request index: %d, name: %q: %d",
+			ifi.Index, ifi.Name, l)
+	}
+
+	return rss[0].Context, nil
+}
+
+// DeleteRSSContext deletes an RSS context from a single ethtool-supported
+// interface.
+func (c *client) DeleteRSSContext(ifi Interface, context int) error {
+	_, err := c.get(
+		_ETHTOOL_A_RSS_HEADER,
+		_ETHTOOL_MSG_RSS_DELETE_ACT,
+		netlink.Acknowledge,
+		ifi,
+		func(ae *netlink.AttributeEncoder) {
+			ae.Uint32(_ETHTOOL_A_RSS_CONTEXT, uint32(context))
+		},
+	)
+	if errors.Is(err, unix.EOPNOTSUPP) {
+		// The kernel predates RSS context deletion over netlink.
+		return c.rxfhDelete(ifi, context)
+	}
+
+	return err
+}
+
+// rssHashFuncs fetches the names of the RSS hash functions known to the
+// kernel, indexed by their bit positions.
+func (c *client) rssHashFuncs() ([]string, error) {
+	sets, err := c.StringSets(Interface{}, StringSetRSSHashFuncs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range sets {
+		if s.ID == StringSetRSSHashFuncs {
+			return s.Strings, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// rssHashFunc looks up the bit for the named RSS hash function, returning 0 if
+// name is nil.
+func (c *client) rssHashFunc(name *string) (uint32, error) {
+	if name == nil {
+		return 0, nil
+	}
+
+	names, err := c.rssHashFuncs()
+	if err != nil {
+		return 0, err
+	}
+
+	i := slices.Index(names, *name)
+	if i == -1 {
+		return 0, fmt.Errorf("ethtool: unknown RSS hash function: %q", *name)
+	}
+
+	return 1 << i, nil
+}
+
+// SetRSS configures a single RSS context on a single ethtool-supported
+// interface.
+func (c *client) SetRSS(ifi Interface, context int, ru *RSSUpdate) error {
+	hfunc, err := c.rssHashFunc(ru.HashFunction)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.get(
+		_ETHTOOL_A_RSS_HEADER,
+		_ETHTOOL_MSG_RSS_SET,
+		netlink.Acknowledge,
+		ifi,
+		func(ae *netlink.AttributeEncoder) {
+			if context != 0 {
+				ae.Uint32(_ETHTOOL_A_RSS_CONTEXT, uint32(context))
+			}
+			if hfunc != 0 {
+				ae.Uint32(_ETHTOOL_A_RSS_HFUNC, hfunc)
+			}
+			ru.encode(ae)
+		},
+	)
+	return err
+}
+
+// encode packs RSSUpdate data into the appropriate netlink attributes for the
+// encoder. The hash function is encoded separately as it requires a string
+// set lookup.
+func (ru *RSSUpdate) encode(ae *netlink.AttributeEncoder) {
+	if ru.IndirectionTable != nil {
+		b := make([]byte, 0, 4*len(ru.IndirectionTable))
+		for _, q := range ru.IndirectionTable {
+			b = binary.NativeEndian.AppendUint32(b, uint32(q))
+		}
+		ae.Bytes(_ETHTOOL_A_RSS_INDIR, b)
+	}
+	if ru.HashKey != nil {
+		ae.Bytes(_ETHTOOL_A_RSS_HKEY, ru.HashKey)
+	}
+	if ru.InputTransform != nil {
+		ae.Uint32(_ETHTOOL_A_RSS_INPUT_XFRM, uint32(*ru.InputTransform))
+	}
+}
+
+// TODO: get these into x/sys/unix
+const (
+	_ETHTOOL_MSG_RSS_SET        = 0x30 //nolint:revive
+	_ETHTOOL_MSG_RSS_CREATE_ACT = 0x31 //nolint:revive
+	_ETHTOOL_MSG_RSS_DELETE_ACT = 0x32 //nolint:revive
+)
+
+const (
+	_ETHTOOL_A_RSS_UNSPEC        = iota //nolint:revive
+	_ETHTOOL_A_RSS_HEADER               //nolint:revive
+	_ETHTOOL_A_RSS_CONTEXT              //nolint:revive
+	_ETHTOOL_A_RSS_HFUNC                //nolint:revive
+	_ETHTOOL_A_RSS_INDIR                //nolint:revive
+	_ETHTOOL_A_RSS_HKEY                 //nolint:revive
+	_ETHTOOL_A_RSS_INPUT_XFRM           //nolint:revive
+	_ETHTOOL_A_RSS_START_CONTEXT        //nolint:revive
+)
+
+// parseRSS parses RSS structures from a slice of generic netlink messages,
+// along with the raw hash function bitmask of each.
+func parseRSS(msgs []genetlink.Message) ([]*RSS, []uint32, error) {
+	var (
+		rss    = make([]*RSS, 0, len(msgs))
+		hfuncs = make([]uint32, 0, len(msgs))
+	)
+
+	for _, m := range msgs {
+		ad, err := netlink.NewAttributeDecoder(m.Data)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var (
+			r     RSS
+			hfunc uint32
+		)
+		for ad.Next() {
+			switch ad.Type() {
+			case _ETHTOOL_A_RSS_HEADER:
+				ad.Nested(parseInterface(&r.Interface))
+			case _ETHTOOL_A_RSS_CONTEXT:
+				r.Context = int(ad.Uint32())
+			case _ETHTOOL_A_RSS_HFUNC:
+				hfunc = ad.Uint32()
+			case _ETHTOOL_A_RSS_INDIR:
+				// The table is a packed array of native endian uint32s.
+				ad.Do(func(b []byte) error {
+					if len(b)%4 != 0 {
+						return fmt.Errorf("ethtool: invalid RSS indirection table length: %d", len(b))
+					}
+
+					r.IndirectionTable = make([]int, 0, len(b)/4)
+					for i := 0; i < len(b); i += 4 {
+						r.IndirectionTable = append(r.IndirectionTable, int(binary.NativeEndian.Uint32(b[i:i+4])))
+					}
+					return nil
+				})
+			case _ETHTOOL_A_RSS_HKEY:
+				r.HashKey = ad.Bytes()
+			case _ETHTOOL_A_RSS_INPUT_XFRM:
+				r.InputTransform = RSSInputTransform(ad.Uint32())
+			}
+		}
+
+		if err := ad.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		rss = append(rss, &r)
+		hfuncs = append(hfuncs, hfunc)
+	}
+
+	return rss, hfuncs, nil
+}
+
+// PLCAConfig fetches the PLCA configuration for a single ethtool-supported
+// link.
+func (c *client) PLCAConfig(ifi Interface) (*PLCAConfig, error) {
+	msgs, err := c.get(
+		_ETHTOOL_A_PLCA_HEADER,
+		unix.ETHTOOL_MSG_PLCA_GET_CFG,
+		0,
+		ifi,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pcs, err := parsePLCAConfig(msgs)
+	if err != nil {
+		return nil, err
+	}
+
+	if l := len(pcs); l != 1 {
+		panicf("ethtool: unexpected number of PLCAConfig messages for request index: %d, name: %q: %d",
+			ifi.Index, ifi.Name, l)
+	}
+
+	return pcs[0], nil
+}
+
+// SetPLCAConfig configures PLCA settings for a single ethtool-supported
+// interface.
+func (c *client) SetPLCAConfig(ifi Interface, pu *PLCAConfigUpdate) error {
+	_, err := c.get(
+		_ETHTOOL_A_PLCA_HEADER,
+		unix.ETHTOOL_MSG_PLCA_SET_CFG,
+		netlink.Acknowledge,
+		ifi,
+		pu.encode,
+	)
+	return err
+}
+
+// encode packs PLCAConfigUpdate data into the appropriate netlink attributes
+// for the encoder.
+func (pu *PLCAConfigUpdate) encode(ae *netlink.AttributeEncoder) {
+	encodeBool(ae, _ETHTOOL_A_PLCA_ENABLED, pu.Enabled)
+
+	if pu.NodeCount != nil {
+		ae.Uint32(_ETHTOOL_A_PLCA_NODE_CNT, uint32(*pu.NodeCount))
+	}
+	if pu.NodeID != nil {
+		ae.Uint32(_ETHTOOL_A_PLCA_NODE_ID, uint32(*pu.NodeID))
+	}
+	if pu.TOTimerBitTimes != nil {
+		ae.Uint32(_ETHTOOL_A_PLCA_TO_TMR, uint32(*pu.TOTimerBitTimes))
+	}
+	if pu.BurstCount != nil {
+		ae.Uint32(_ETHTOOL_A_PLCA_BURST_CNT, uint32(*pu.BurstCount))
+	}
+	if pu.BurstTimerBitTimes != nil {
+		ae.Uint32(_ETHTOOL_A_PLCA_BURST_TMR, uint32(*pu.BurstTimerBitTimes))
+	}
+}
+
+// PLCAStatus fetches the PLCA status for a single ethtool-supported link.
+func (c *client) PLCAStatus(ifi Interface) (*PLCAStatus, error) {
+	msgs, err := c.get(
+		_ETHTOOL_A_PLCA_HEADER,
+		unix.ETHTOOL_MSG_PLCA_GET_STATUS,
+		0,
+		ifi,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if l := len(msgs); l != 1 {
+		panicf("ethtool: unexpected number of PLCAStatus messages for request index: %d, name: %q: %d",
+			ifi.Index, ifi.Name, l)
+	}
+
+	ad, err := netlink.NewAttributeDecoder(msgs[0].Data)
+	if err != nil {
+		return nil, err
+	}
+
+	var ps PLCAStatus
+	for ad.Next() {
+		switch ad.Type() {
+		case _ETHTOOL_A_PLCA_HEADER:
+			ad.Nested(parseInterface(&ps.Interface))
+		case _ETHTOOL_A_PLCA_STATUS:
+			ps.Active = ad.Uint8() != 0
+		}
+	}
+
+	if err := ad.Err(); err != nil {
+		return nil, err
+	}
+
+	return &ps, nil
+}
+
+// TODO: get these into x/sys/unix
+const (
+	_ETHTOOL_A_PLCA_UNSPEC    = iota //nolint:revive
+	_ETHTOOL_A_PLCA_HEADER           //nolint:revive
+	_ETHTOOL_A_PLCA_VERSION          //nolint:revive
+	_ETHTOOL_A_PLCA_ENABLED          //nolint:revive
+	_ETHTOOL_A_PLCA_STATUS           //nolint:revive
+	_ETHTOOL_A_PLCA_NODE_CNT         //nolint:revive
+	_ETHTOOL_A_PLCA_NODE_ID          //nolint:revive
+	_ETHTOOL_A_PLCA_TO_TMR           //nolint:revive
+	_ETHTOOL_A_PLCA_BURST_CNT        //nolint:revive
+	_ETHTOOL_A_PLCA_BURST_TMR        //nolint:revive
+)
+
+// parsePLCAConfig parses PLCAConfig structures from a slice of generic netlink
+// messages.
+func parsePLCAConfig(msgs []genetlink.Message) ([]*PLCAConfig, error) {
+	pcs := make([]*PLCAConfig, 0, len(msgs))
+	for _, m := range msgs {
+		ad, err := netlink.NewAttributeDecoder(m.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		var pc PLCAConfig
+		for ad.Next() {
+			switch ad.Type() {
+			case _ETHTOOL_A_PLCA_HEADER:
+				ad.Nested(parseInterface(&pc.Interface))
+			case _ETHTOOL_A_PLCA_VERSION:
+				pc.Version = ad.Uint16()
+			case _ETHTOOL_A_PLCA_ENABLED:
+				pc.Enabled = ad.Uint8() != 0
+			case _ETHTOOL_A_PLCA_NODE_CNT:
+				pc.NodeCount = int(ad.Uint32())
+			case _ETHTOOL_A_PLCA_NODE_ID:
+				pc.NodeID = int(ad.Uint32())
+			case _ETHTOOL_A_PLCA_TO_TMR:
+				pc.TOTimerBitTimes = int(ad.Uint32())
+			case _ETHTOOL_A_PLCA_BURST_CNT:
+				pc.BurstCount = int(ad.Uint32())
+			case _ETHTOOL_A_PLCA_BURST_TMR:
+				pc.BurstTimerBitTimes = int(ad.Uint32())
+			}
+		}
+
+		if err := ad.Err(); err != nil {
+			return nil, err
+		}
+
+		pcs = append(pcs, &pc)
+	}
+
+	return pcs, nil
+}
+
+// ModuleEEPROM reads raw data from the transceiver module EEPROM of a single
+// ethtool-supported link.
+func (c *client) ModuleEEPROM(ifi Interface, req ModuleEEPROMRequest) ([]byte, error) {
+	msgs, err := c.get(
+		_ETHTOOL_A_MODULE_EEPROM_HEADER,
+		unix.ETHTOOL_MSG_MODULE_EEPROM_GET,
+		0,
+		ifi,
+		req.encode,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if l := len(msgs); l != 1 {
+		panicf("ethtool: unexpected number of ModuleEEPROM messages for request index: %d, name: %q: %d",
+			ifi.Index, ifi.Name, l)
+	}
+
+	return parseModuleEEPROM(msgs[0])
+}
+
+// encode packs ModuleEEPROMRequest data into the appropriate netlink
+// attributes for the encoder.
+func (req ModuleEEPROMRequest) encode(ae *netlink.AttributeEncoder) {
+	ae.Uint32(_ETHTOOL_A_MODULE_EEPROM_OFFSET, uint32(req.Offset))
+	ae.Uint32(_ETHTOOL_A_MODULE_EEPROM_LENGTH, uint32(req.Length))
+	ae.Uint8(_ETHTOOL_A_MODULE_EEPROM_PAGE, uint8(req.Page))
+	ae.Uint8(_ETHTOOL_A_MODULE_EEPROM_BANK, uint8(req.Bank))
+	ae.Uint8(_ETHTOOL_A_MODULE_EEPROM_I2C_ADDRESS, uint8(req.I2CAddress))
+}
+
+// TODO: get these into x/sys/unix
+const (
+	_ETHTOOL_A_MODULE_EEPROM_UNSPEC      = iota //nolint:revive
+	_ETHTOOL_A_MODULE_EEPROM_HEADER             //nolint:revive
+	_ETHTOOL_A_MODULE_EEPROM_OFFSET             //nolint:revive
+	_ETHTOOL_A_MODULE_EEPROM_LENGTH             //nolint:revive
+	_ETHTOOL_A_MODULE_EEPROM_PAGE               //nolint:revive
+	_ETHTOOL_A_MODULE_EEPROM_BANK               //nolint:revive
+	_ETHTOOL_A_MODULE_EEPROM_I2C_ADDRESS        //nolint:revive
+	_ETHTOOL_A_MODULE_EEPROM_DATA               //nolint:revive
+)
+
+// parseModuleEEPROM parses module EEPROM data from a generic netlink message.
+func parseModuleEEPROM(m genetlink.Message) ([]byte, error) {
+	ad, err := netlink.NewAttributeDecoder(m.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	var b []byte
+	for ad.Next() {
+		if ad.Type() == _ETHTOOL_A_MODULE_EEPROM_DATA {
+			b = ad.Bytes()
+		}
+	}
+
+	if err := ad.Err(); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// AllStats fetches standard statistics for all ethtool-supported links.
+func (c *client) AllStats(req StatsRequest) ([]*Stats, error) {
+	return c.stats(netlink.Dump, Interface{}, req)
+}
+
+// Stats fetches standard statistics for a single ethtool-supported link.
+func (c *client) Stats(ifi Interface, req StatsRequest) (*Stats, error) {
+	ss, err := c.stats(0, ifi, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if l := len(ss); l != 1 {
+		panicf("ethtool: unexpected number of Stats messages for request index: %d, name: %q: %d",
+			ifi.Index, ifi.Name, l)
+	}
+
+	return ss[0], nil
+}
+
+// stats is the shared logic for Client.(All)Stats.
+func (c *client) stats(flags netlink.HeaderFlags, ifi Interface, req StatsRequest) ([]*Stats, error) {
+	msgs, err := c.get(
+		_ETHTOOL_A_STATS_HEADER,
+		unix.ETHTOOL_MSG_STATS_GET,
+		flags,
+		ifi,
+		req.encode,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseStats(msgs)
+}
+
+// encode packs StatsRequest data into the appropriate netlink attributes for
+// the encoder.
+func (req StatsRequest) encode(ae *netlink.AttributeEncoder) {
+	groups := req.Groups
+	if len(groups) == 0 {
+		groups = []StatsGroup{StatsEthPHY, StatsEthMAC, StatsEthCtrl, StatsRMON}
+	}
+
+	names := make(map[string]bool, len(groups))
+	for _, g := range groups {
+		names[g.String()] = true
+	}
+	encodeNamedBitset(ae, _ETHTOOL_A_STATS_GROUPS, names)
+
+	// Kernels without MAC Merge support reject the source attribute, so only
+	// send it when a specific MAC is requested.
+	if req.Source != StatsSourceAggregate {
+		ae.Uint32(_ETHTOOL_A_STATS_SRC, uint32(req.Source))
+	}
+}
+
+// TODO: get these into x/sys/unix
+const (
+	_ETHTOOL_A_STATS_UNSPEC = iota //nolint:revive
+	_ETHTOOL_A_STATS_PAD           //nolint:revive
+	_ETHTOOL_A_STATS_HEADER        //nolint:revive
+	_ETHTOOL_A_STATS_GROUPS        //nolint:revive
+	_ETHTOOL_A_STATS_GRP           //nolint:revive
+	_ETHTOOL_A_STATS_SRC           //nolint:revive
+)
+
+const (
+	_ETHTOOL_A_STATS_GRP_UNSPEC       = iota //nolint:revive
+	_ETHTOOL_A_STATS_GRP_PAD                 //nolint:revive
+	_ETHTOOL_A_STATS_GRP_ID                  //nolint:revive
+	_ETHTOOL_A_STATS_GRP_SS_ID               //nolint:revive
+	_ETHTOOL_A_STATS_GRP_STAT                //nolint:revive
+	_ETHTOOL_A_STATS_GRP_HIST_RX             //nolint:revive
+	_ETHTOOL_A_STATS_GRP_HIST_TX             //nolint:revive
+	_ETHTOOL_A_STATS_GRP_HIST_BKT_LOW        //nolint:revive
+	_ETHTOOL_A_STATS_GRP_HIST_BKT_HI         //nolint:revive
+	_ETHTOOL_A_STATS_GRP_HIST_VAL            //nolint:revive
+)
+
+// parseStats parses Stats structures from a slice of generic netlink messages.
+func parseStats(msgs []genetlink.Message) ([]*Stats, error) {
+	ss := make([]*Stats, 0, len(msgs))
+	for _, m := range msgs {
+		ad, err := netlink.NewAttributeDecoder(m.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		var s Stats
+		for ad.Next() {
+			switch ad.Type() {
+			case _ETHTOOL_A_STATS_HEADER:
+				ad.Nested(parseInterface(&s.Interface))
+			case _ETHTOOL_A_STATS_GRP:
+				ad.Nested(parseStatsGroup(&s))
+			}
+		}
+
+		if err := ad.Err(); err != nil {
+			return nil, err
+		}
+
+		ss = append(ss, &s)
+	}
+
+	return ss, nil
+}
+
+// parseStatsGroup parses a single group of statistics into s.
+func parseStatsGroup(s *Stats) func(*netlink.AttributeDecoder) error {
+	return func(ad *netlink.AttributeDecoder) error {
+		var (
+			id     = -1
+			values = make(map[uint16]uint64)
+			rx, tx []RMONHistogramBucket
+		)
+
+		for ad.Next() {
+			switch ad.Type() {
+			case _ETHTOOL_A_STATS_GRP_ID:
+				id = int(ad.Uint32())
+			case _ETHTOOL_A_STATS_GRP_STAT:
+				// Each statistic is nested in an attribute whose type is the
+				// index of the statistic within its group.
+				ad.Nested(func(nad *netlink.AttributeDecoder) error {
+					for nad.Next() {
+						// Skip any padding used to align the value.
+						if len(nad.Bytes()) != 8 {
+							continue
+						}
+
+						values[nad.Type()] = nad.Uint64()
+					}
+					return nad.Err()
+				})
+			case _ETHTOOL_A_STATS_GRP_HIST_RX:
+				ad.Nested(parseRMONHistogramBucket(&rx))
+			case _ETHTOOL_A_STATS_GRP_HIST_TX:
+				ad.Nested(parseRMONHistogramBucket(&tx))
+			}
+		}
+
+		if err := ad.Err(); err != nil {
+			return err
+		}
+
+		// Each group's statistics are stored in the same order as the
+		// kernel's indices for the group.
+		var fields []*uint64
+		switch StatsGroup(id) {
+		case StatsEthPHY:
+			s.EthPHY = &EthPHYStats{}
+			fields = []*uint64{&s.EthPHY.SymbolErrorDuringCarrier}
+		case StatsEthMAC:
+			m := &EthMACStats{}
+			s.EthMAC = m
+			fields = []*uint64{
+				&m.FramesTransmittedOK,
+				&m.SingleCollisionFrames,
+				&m.MultipleCollisionFrames,
+				&m.FramesReceivedOK,
+				&m.FrameCheckSequenceErrors,
+				&m.AlignmentErrors,
+				&m.OctetsTransmittedOK,
+				&m.FramesWithDeferredXmissions,
+				&m.LateCollisions,
+				&m.FramesAbortedDueToXSColls,
+				&m.FramesLostDueToIntMACXmitError,
+				&m.CarrierSenseErrors,
+				&m.OctetsReceivedOK,
+				&m.FramesLostDueToIntMACRcvError,
+				&m.MulticastFramesXmittedOK,
+				&m.BroadcastFramesXmittedOK,
+				&m.FramesWithExcessiveDeferral,
+				&m.MulticastFramesReceivedOK,
+				&m.BroadcastFramesReceivedOK,
+				&m.InRangeLengthErrors,
+				&m.OutOfRangeLengthField,
+				&m.FrameTooLongErrors,
+			}
+		case StatsEthCtrl:
+			c := &EthCtrlStats{}
+			s.EthCtrl = c
+			fields = []*uint64{
+				&c.MACControlFramesTransmitted,
+				&c.MACControlFramesReceived,
+				&c.UnsupportedOpcodesReceived,
+			}
+		case StatsRMON:
+			r := &RMONStats{RXHistogram: rx, TXHistogram: tx}
+			s.RMON = r
+			fields = []*uint64{
+				&r.UndersizePkts,
+				&r.OversizePkts,
+				&r.Fragments,
+				&r.Jabbers,
+			}
+		default:
+			// Unknown group, ignore it.
+			return nil
+		}
+
+		for i, v := range values {
+			if int(i) < len(fields) {
+				*fields[i] = v
+			}
+		}
+
+		return nil
+	}
+}
+
+// parseRMONHistogramBucket parses a single RMON histogram bucket and appends
+// it to bs.
+func parseRMONHistogramBucket(bs *[]RMONHistogramBucket) func(*netlink.AttributeDecoder) error {
+	return func(ad *netlink.AttributeDecoder) error {
+		var b RMONHistogramBucket
+		for ad.Next() {
+			switch ad.Type() {
+			case _ETHTOOL_A_STATS_GRP_HIST_BKT_LOW:
+				b.Low = int(ad.Uint32())
+			case _ETHTOOL_A_STATS_GRP_HIST_BKT_HI:
+				b.High = int(ad.Uint32())
+			case _ETHTOOL_A_STATS_GRP_HIST_VAL:
+				b.Packets = ad.Uint64()
+			}
+		}
+
+		if err := ad.Err(); err != nil {
+			return err
+		}
+
+		*bs = append(*bs, b)
+		return nil
+	}
+}
+
+// encodeBool packs an optional boolean into a uint8 attribute, which is how
+// ethtool represents most boolean values.
+func encodeBool(ae *netlink.AttributeEncoder, typ uint16, v *bool) {
+	if v == nil {
+		return
+	}
+
+	var b uint8
+	if *v {
+		b = 1
+	}
+	ae.Uint8(typ, b)
+}
+
+// StringSets fetches string sets for a single interface, or the global string
+// sets if no interface is specified.
+func (c *client) StringSets(ifi Interface, ids ...StringSetID) ([]*StringSet, error) {
+	msgs, err := c.get(
+		unix.ETHTOOL_A_STRSET_HEADER,
+		unix.ETHTOOL_MSG_STRSET_GET,
+		0,
+		ifi,
+		func(ae *netlink.AttributeEncoder) {
+			if len(ids) == 0 {
+				// No string sets specified, the kernel returns all of them.
+				return
+			}
+
+			ae.Nested(unix.ETHTOOL_A_STRSET_STRINGSETS, func(nae *netlink.AttributeEncoder) error {
+				for _, id := range ids {
+					nae.Nested(unix.ETHTOOL_A_STRINGSETS_STRINGSET, func(nnae *netlink.AttributeEncoder) error {
+						nnae.Uint32(unix.ETHTOOL_A_STRINGSET_ID, uint32(id))
+						return nil
+					})
+				}
+				return nil
+			})
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseStringSets(msgs)
+}
+
+// parseStringSets parses StringSet structures from a slice of generic netlink
+// messages.
+func parseStringSets(msgs []genetlink.Message) ([]*StringSet, error) {
+	var sets []*StringSet
+	for _, m := range msgs {
+		ad, err := netlink.NewAttributeDecoder(m.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		for ad.Next() {
+			switch ad.Type() {
+			case unix.ETHTOOL_A_STRSET_STRINGSETS:
+				ad.Nested(func(nad *netlink.AttributeDecoder) error {
+					for nad.Next() {
+						if nad.Type() != unix.ETHTOOL_A_STRINGSETS_STRINGSET {
+							continue
+						}
+
+						var set StringSet
+						nad.Nested(parseStringSet(&set))
+						sets = append(sets, &set)
+					}
+					return nad.Err()
+				})
+			}
+		}
+
+		if err := ad.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return sets, nil
+}
+
+// parseStringSet decodes a single string set into the input StringSet.
+func parseStringSet(set *StringSet) func(*netlink.AttributeDecoder) error {
+	return func(ad *netlink.AttributeDecoder) error {
+		for ad.Next() {
+			switch ad.Type() {
+			case unix.ETHTOOL_A_STRINGSET_ID:
+				set.ID = StringSetID(ad.Uint32())
+			case unix.ETHTOOL_A_STRINGSET_COUNT:
+				// The count always precedes the strings themselves.
+				set.Strings = make([]string, ad.Uint32())
+			case unix.ETHTOOL_A_STRINGSET_STRINGS:
+				ad.Nested(func(nad *netlink.AttributeDecoder) error {
+					for nad.Next() {
+						if nad.Type() != unix.ETHTOOL_A_STRINGS_STRING {
+							continue
+						}
+
+						nad.Nested(func(nnad *netlink.AttributeDecoder) error {
+							var (
+								idx uint32
+								str string
+							)
+							for nnad.Next() {
+								switch nnad.Type() {
+								case unix.ETHTOOL_A_STRING_INDEX:
+									idx = nnad.Uint32()
+								case unix.ETHTOOL_A_STRING_VALUE:
+									str = nnad.String()
+								}
+							}
+
+							// Compare as uint32 so a large index can't overflow int on
+							// 32-bit platforms.
+							if idx >= uint32(len(set.Strings)) {
+								return fmt.Errorf("ethtool: string index %d out of range for string set %d with count %d",
+									idx, set.ID, len(set.Strings))
+							}
+
+							set.Strings[idx] = str
+							return nnad.Err()
+						})
+					}
+					return nad.Err()
+				})
+			}
+		}
+		return ad.Err()
+	}
+}
+
+// Monitor receives change notifications from the ethtool monitor multicast
+// group.
+func (c *client) Monitor(ctx context.Context) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		conn, err := c.dialMonitor()
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer conn.Close()
+
+		err = receive(ctx, conn, func(m genetlink.Message) (bool, error) {
+			ev, err := c.parseEvent(m)
+			if err != nil {
+				return false, err
+			}
+			if ev == nil {
+				// Not a notification we know how to handle, keep going.
+				return true, nil
+			}
+
+			return yield(ev, nil), nil
+		})
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// receive receives multicast messages from conn and invokes fn for each of
+// them until fn returns false or an error, or ctx is canceled.
+func receive(ctx context.Context, conn *genetlink.Conn, fn func(m genetlink.Message) (bool, error)) error {
+	// Interrupt any blocking Receive calls if ctx is canceled before we're done.
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.SetReadDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		msgs, _, err := conn.Receive()
+		if err != nil {
+			if cerr := ctx.Err(); cerr != nil {
+				// The deadline was most likely set due to cancelation, report
+				// that instead of the timeout.
+				return cerr
+			}
+
+			return err
+		}
+
+		for _, m := range msgs {
+			ok, err := fn(m)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return nil
+			}
+		}
+	}
+}
+
+// CableTest runs a cable test on the specified Interface and waits for the
+// results.
+func (c *client) CableTest(ctx context.Context, ifi Interface) (*CableTestResult, error) {
+	m, err := c.cableTest(
+		ctx,
+		unix.ETHTOOL_A_CABLE_TEST_HEADER,
+		unix.ETHTOOL_MSG_CABLE_TEST_ACT,
+		unix.ETHTOOL_MSG_CABLE_TEST_NTF,
+		ifi,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCableTest(m)
+}
+
+// cableTest is the shared logic for Client.CableTest(TDR). It starts a cable
+// test with the act command and returns the notification of type ntf which
+// reports that the test has completed on ifi.
+func (c *client) cableTest(
+	ctx context.Context,
+	header uint16,
+	act, ntf uint8,
+	ifi Interface,
+	// May be nil; used to apply optional parameters.
+	params func(ae *netlink.AttributeEncoder),
+) (genetlink.Message, error) {
+	// The results are only reported via the monitor multicast group, so we
+	// must join it before starting the test to avoid missing them.
+	conn, err := c.dialMonitor()
+	if err != nil {
+		return genetlink.Message{}, err
+	}
+	defer conn.Close()
+
+	if _, err := c.get(header, act, netlink.Acknowledge, ifi, params); err != nil {
+		return genetlink.Message{}, err
+	}
+
+	var done genetlink.Message
+	err = receive(ctx, conn, func(m genetlink.Message) (bool, error) {
+		if m.Header.Command != ntf {
+			return true, nil
+		}
+
+		// Both cable test notification types share the same header and status
+		// attributes.
+		var (
+			nifi   Interface
+			status uint8
+		)
+
+		ad, err := netlink.NewAttributeDecoder(m.Data)
+		if err != nil {
+			return false, err
+		}
+
+		for ad.Next() {
+			switch ad.Type() {
+			case unix.ETHTOOL_A_CABLE_TEST_NTF_HEADER:
+				ad.Nested(parseInterface(&nifi))
+			case unix.ETHTOOL_A_CABLE_TEST_NTF_STATUS:
+				status = ad.Uint8()
+			}
+		}
+
+		if err := ad.Err(); err != nil {
+			return false, err
+		}
+
+		if status != unix.ETHTOOL_A_CABLE_TEST_NTF_STATUS_COMPLETED ||
+			(ifi.Index != 0 && ifi.Index != nifi.Index) ||
+			(ifi.Name != "" && ifi.Name != nifi.Name) {
+			// Not done yet, or a test running on another interface.
+			return true, nil
+		}
+
+		done = m
+		return false, nil
+	})
+	if err != nil {
+		return genetlink.Message{}, err
+	}
+
+	return done, nil
+}
+
+// parseCableTest parses a CableTestResult from a completed cable test
+// notification.
+func parseCableTest(m genetlink.Message) (*CableTestResult, error) {
+	ad, err := netlink.NewAttributeDecoder(m.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	var ctr CableTestResult
+
+	// Results and fault lengths are reported separately, so merge them into a
+	// single entry for each pair.
+	pair := func(p CablePair) *CableTestPair {
+		for i := range ctr.Pairs {
+			if ctr.Pairs[i].Pair == p {
+				return &ctr.Pairs[i]
+			}
+		}
+
+		ctr.Pairs = append(ctr.Pairs, CableTestPair{
+			Pair:                   p,
+			FaultLengthCentimeters: -1,
+		})
+		return &ctr.Pairs[len(ctr.Pairs)-1]
+	}
+
+	for ad.Next() {
+		switch ad.Type() {
+		case unix.ETHTOOL_A_CABLE_TEST_NTF_HEADER:
+			ad.Nested(parseInterface(&ctr.Interface))
+		case unix.ETHTOOL_A_CABLE_TEST_NTF_NEST:
+			ad.Nested(func(nad *netlink.AttributeDecoder) error {
+				for nad.Next() {
+					switch nad.Type() {
+					case unix.ETHTOOL_A_CABLE_NEST_RESULT:
+						nad.Nested(func(nnad *netlink.AttributeDecoder) error {
+							var (
+								p    CablePair
+								code CableTestCode
+							)
+							for nnad.Next() {
+								switch nnad.Type() {
+								case unix.ETHTOOL_A_CABLE_RESULT_PAIR:
+									p = CablePair(nnad.Uint8())
+								case unix.ETHTOOL_A_CABLE_RESULT_CODE:
+									code = CableTestCode(nnad.Uint8())
+								}
+							}
+							pair(p).Code = code
+							return nnad.Err()
+						})
+					case unix.ETHTOOL_A_CABLE_NEST_FAULT_LENGTH:
+						nad.Nested(func(nnad *netlink.AttributeDecoder) error {
+							var (
+								p  CablePair
+								cm int
+							)
+							for nnad.Next() {
+								switch nnad.Type() {
+								case unix.ETHTOOL_A_CABLE_FAULT_LENGTH_PAIR:
+									p = CablePair(nnad.Uint8())
+								case unix.ETHTOOL_A_CABLE_FAULT_LENGTH_CM:
+									cm = int(nnad.Uint32())
+								}
+							}
+							pair(p).FaultLengthCentimeters = cm
+							return nnad.Err()
+						})
+					}
+				}
+				return nad.Err()
+			})
+		}
+	}
+
+	if err := ad.Err(); err != nil {
+		return nil, err
+	}
+
+	return &ctr, nil
+}
+
+// CableTestTDR runs a time-domain reflectometry cable test on the specified
+// Interface and waits for the results.
+func (c *client) CableTestTDR(ctx context.Context, ifi Interface, cfg *CableTestTDRConfig) (*CableTestTDRResult, error) {
+	m, err := c.cableTest(
+		ctx,
+		unix.ETHTOOL_A_CABLE_TEST_TDR_HEADER,
+		unix.ETHTOOL_MSG_CABLE_TEST_TDR_ACT,
+		unix.ETHTOOL_MSG_CABLE_TEST_TDR_NTF,
+		ifi,
+		cfg.encode,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCableTestTDR(m)
+}
+
+// encode packs CableTestTDRConfig data into the appropriate netlink attributes
+// for the encoder.
+func (cfg *CableTestTDRConfig) encode(ae *netlink.AttributeEncoder) {
+	if cfg == nil {
+		return
+	}
+
+	ae.Nested(unix.ETHTOOL_A_CABLE_TEST_TDR_CFG, func(nae *netlink.AttributeEncoder) error {
+		u32 := func(typ uint16, v *int) {
+			if v != nil {
+				nae.Uint32(typ, uint32(*v))
+			}
+		}
+
+		u32(unix.ETHTOOL_A_CABLE_TEST_TDR_CFG_FIRST, cfg.FirstCentimeters)
+		u32(unix.ETHTOOL_A_CABLE_TEST_TDR_CFG_LAST, cfg.LastCentimeters)
+		u32(unix.ETHTOOL_A_CABLE_TEST_TDR_CFG_STEP, cfg.StepCentimeters)
+		if cfg.Pair != nil {
+			nae.Uint8(unix.ETHTOOL_A_CABLE_TEST_TDR_CFG_PAIR, uint8(*cfg.Pair))
+		}
+		return nil
+	})
+}
+
+// parseCableTestTDR parses a CableTestTDRResult from a completed TDR cable
+// test notification.
+func parseCableTestTDR(m genetlink.Message) (*CableTestTDRResult, error) {
+	ad, err := netlink.NewAttributeDecoder(m.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		r CableTestTDRResult
+
+		// Amplitudes don't carry a distance, so track the current step and
+		// the number of samples for each pair since it was reported to
+		// compute one.
+		step  *CableTestTDRStep
+		count = make(map[CablePair]int)
+	)
+
+	pair := func(p CablePair) *CableTestTDRPair {
+		for i := range r.Pairs {
+			if r.Pairs[i].Pair == p {
+				return &r.Pairs[i]
+			}
+		}
+
+		r.Pairs = append(r.Pairs, CableTestTDRPair{Pair: p})
+		return &r.Pairs[len(r.Pairs)-1]
+	}
+
+	for ad.Next() {
+		switch ad.Type() {
+		case unix.ETHTOOL_A_CABLE_TEST_TDR_NTF_HEADER:
+			ad.Nested(parseInterface(&r.Interface))
+		case unix.ETHTOOL_A_CABLE_TEST_TDR_NTF_NEST:
+			ad.Nested(func(nad *netlink.AttributeDecoder) error {
+				for nad.Next() {
+					switch nad.Type() {
+					case unix.ETHTOOL_A_CABLE_TDR_NEST_STEP:
+						nad.Nested(func(nnad *netlink.AttributeDecoder) error {
+							var s CableTestTDRStep
+							for nnad.Next() {
+								switch nnad.Type() {
+								case unix.ETHTOOL_A_CABLE_STEP_FIRST_DISTANCE:
+									s.FirstCentimeters = int(nnad.Uint32())
+								case unix.ETHTOOL_A_CABLE_STEP_LAST_DISTANCE:
+									s.LastCentimeters = int(nnad.Uint32())
+								case unix.ETHTOOL_A_CABLE_STEP_STEP_DISTANCE:
+									s.StepCentimeters = int(nnad.Uint32())
+								}
+							}
+
+							r.Steps = append(r.Steps, s)
+							step = &s
+							clear(count)
+							return nnad.Err()
+						})
+					case unix.ETHTOOL_A_CABLE_TDR_NEST_AMPLITUDE:
+						nad.Nested(func(nnad *netlink.AttributeDecoder) error {
+							var (
+								p  CablePair
+								mv int
+							)
+							for nnad.Next() {
+								switch nnad.Type() {
+								case unix.ETHTOOL_A_CABLE_AMPLITUDE_PAIR:
+									p = CablePair(nnad.Uint8())
+								case unix.ETHTOOL_A_CABLE_AMPLITUDE_mV:
+									mv = int(nnad.Int16())
+								}
+							}
+
+							distance := -1
+							if step != nil {
+								distance = step.FirstCentimeters + count[p]*step.StepCentimeters
+								count[p]++
+							}
+
+							tp := pair(p)
+							tp.Samples = append(tp.Samples, CableTestTDRSample{
+								DistanceCentimeters: distance,
+								AmplitudeMillivolts: mv,
+							})
+							return nnad.Err()
+						})
+					case unix.ETHTOOL_A_CABLE_TDR_NEST_PULSE:
+						nad.Nested(func(nnad *netlink.AttributeDecoder) error {
+							for nnad.Next() {
+								if nnad.Type() == unix.ETHTOOL_A_CABLE_PULSE_mV {
+									r.PulsesMillivolts = append(r.PulsesMillivolts, int(nnad.Int16()))
+								}
+							}
+							return nnad.Err()
+						})
+					}
+				}
+				return nad.Err()
+			})
+		}
+	}
+
+	if err := ad.Err(); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// parseEvent parses an Event from a monitor multicast group notification. If
+// the notification is not supported, a nil Event is returned.
+func (c *client) parseEvent(m genetlink.Message) (Event, error) {
+	msgs := []genetlink.Message{m}
+
+	switch m.Header.Command {
+	case unix.ETHTOOL_MSG_LINKINFO_NTF:
+		return firstEvent(parseLinkInfo(msgs))
+	case unix.ETHTOOL_MSG_LINKMODES_NTF:
+		return firstEvent(parseLinkModes(msgs))
+	case unix.ETHTOOL_MSG_WOL_NTF:
+		return firstEvent(parseWakeOnLAN(msgs))
+	case unix.ETHTOOL_MSG_FEC_NTF:
+		return firstEvent(parseFEC(msgs))
+	case unix.ETHTOOL_MSG_RINGS_NTF:
+		return firstEvent(parseRings(msgs))
+	case unix.ETHTOOL_MSG_CHANNELS_NTF:
+		return firstEvent(parseChannels(msgs))
+	case unix.ETHTOOL_MSG_COALESCE_NTF:
+		return firstEvent(parseCoalesce(msgs))
+	case unix.ETHTOOL_MSG_PAUSE_NTF:
+		return firstEvent(parsePause(msgs))
+	case unix.ETHTOOL_MSG_EEE_NTF:
+		return firstEvent(parseEEE(msgs))
+	case unix.ETHTOOL_MSG_MODULE_NTF:
+		return firstEvent(parseModules(msgs))
+	case unix.ETHTOOL_MSG_PLCA_NTF:
+		return firstEvent(parsePLCAConfig(msgs))
+	case unix.ETHTOOL_MSG_PRIVFLAGS_NTF:
+		pfs, err := parsePrivateFlags(msgs)
+		if err != nil || len(pfs) == 0 {
+			return nil, err
+		}
+
+		// Notifications always use compact bitsets which don't carry the
+		// names of the flags, so fetch the current flags by name instead.
+		if pf := pfs[0]; len(pf.Flags) == 0 {
+			return c.PrivateFlags(pf.Interface)
+		}
+
+		return pfs[0], nil
+	case unix.ETHTOOL_MSG_FEATURES_NTF:
+		fs, err := parseFeatures(msgs)
+		if err != nil || len(fs) == 0 {
+			return nil, err
+		}
+
+		// As with private flags, the compact bitsets in the notification can't
+		// be mapped to feature names, so fetch the current features instead.
+		return c.Features(fs[0].Interface)
+	default:
+		return nil, nil
+	}
+}
+
+// firstEvent returns the first element of ts as an Event, or nil if ts is
+// empty.
+func firstEvent[T Event](ts []T, err error) (Event, error) {
+	if err != nil || len(ts) == 0 {
+		return nil, err
+	}
+
+	return ts[0], nil
+}
+
+// get performs a request/response interaction with ethtool netlink.
+func (c *client) get(
+	header uint16,
+	cmd uint8,
+	flags netlink.HeaderFlags,
+	ifi Interface,
+	// May be nil; used to apply optional parameters.
+	params func(ae *netlink.AttributeEncoder),
+) ([]genetlink.Message, error) {
+	return c.getFlags(header, cmd, flags, 0, ifi, params)
+}
+
+// getFlags is like get, but also sets the ETHTOOL_FLAG_* values in hflags in
+// the request header.
+func (c *client) getFlags(
+	header uint16,
+	cmd uint8,
+	flags netlink.HeaderFlags,
+	hflags uint32,
+	ifi Interface,
+	// May be nil; used to apply optional parameters.
+	params func(ae *netlink.AttributeEncoder),
+) ([]genetlink.Message, error) {
+	if flags&netlink.Dump == 0 && ifi.Index == 0 && ifi.Name == "" &&
+		cmd != unix.ETHTOOL_MSG_STRSET_GET {
+		// The caller is not requesting to dump information for multiple
+		// interfaces and thus has to specify some identifier or the kernel will
+		// EINVAL on this path. String sets are the exception, as the global
+		// sets are returned when no interface is specified.
+		return nil, errBadRequest
+	}
+
+	// TODO(mdlayher): make this faster by potentially precomputing the byte
+	// slice of packed netlink attributes and then modifying the index value at
+	// the appropriate byte slice index.
+	ae := netlink.NewAttributeEncoder()
+	ae.Nested(header, func(nae *netlink.AttributeEncoder) error {
+		// When fetching by index or name, one or both will be non-zero.
+		// Otherwise we leave the header empty to dump all the links.
+		//
+		// Note that if the client happens to pass an incompatible non-zero
+		// index/name pair, the kernel will return an error and we'll
+		// immediately send that back.
+		if ifi.Index > 0 {
+			nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(ifi.Index))
+		}
+		if ifi.Name != "" {
+			nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, ifi.Name)
+		}
+
+		// Unconditionally add the compact bitsets flag to all query commands
+		// since the ethtool multicast group notifications require the compact
+		// format, so we might as well always use it.
+		if cmd != unix.ETHTOOL_MSG_FEC_SET &&
+			cmd != unix.ETHTOOL_MSG_WOL_SET &&
+			cmd != unix.ETHTOOL_MSG_PRIVFLAGS_GET &&
+			cmd != unix.ETHTOOL_MSG_PRIVFLAGS_SET &&
+			cmd != unix.ETHTOOL_MSG_FEATURES_GET &&
+			cmd != unix.ETHTOOL_MSG_FEATURES_SET &&
+			cmd != unix.ETHTOOL_MSG_TSINFO_GET {
+			hflags |= unix.ETHTOOL_FLAG_COMPACT_BITSETS
+		}
+		if hflags != 0 {
+			nae.Uint32(unix.ETHTOOL_A_HEADER_FLAGS, hflags)
+		}
+
+		return nil
+	})
+
+	if params != nil {
+		// Optionally apply more parameters to the attribute encoder.
+		params(ae)
+	}
+
+	// Note: don't send netlink.Acknowledge or we get an extra message back from
+	// the kernel which doesn't seem useful as of now.
+	msgs, err := c.execute(cmd, flags, ae)
+	if err != nil {
+		// Unpack the extended acknowledgement error message if possible so the
+		// caller doesn't have to unpack it themselves.
+		var msg string
+		if oerr, ok := err.(*netlink.OpError); ok {
+			msg = oerr.Message
+		}
+
+		return nil, &Error{
+			Message: msg,
+			Err:     err,
+		}
+	}
+
+	return msgs, nil
+}
+
+// execute executes the specified command with additional header flags and input
+// netlink request attributes. The netlink.Request header flag is automatically
+// set.
+func (c *client) execute(cmd uint8, flags netlink.HeaderFlags, ae *netlink.AttributeEncoder) ([]genetlink.Message, error) {
+	b, err := ae.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.c.Execute(
+		genetlink.Message{
+			Header: genetlink.Header{
+				Command: cmd,
+				Version: unix.ETHTOOL_GENL_VERSION,
+			},
+			Data: b,
+		},
+		// Always pass the genetlink family ID and request flag.
+		c.family,
+		netlink.Request|flags,
+	)
+}
+
+// Is enables Error comparison with sentinel errors that are part of the
+// Client's API contract such as os.ErrNotExist and os.ErrPermission.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case os.ErrNotExist:
+		// The queried interface is not supported by the ethtool APIs
+		// (EOPNOTSUPP) or does not exist at all (ENODEV).
+		return errors.Is(e.Err, unix.EOPNOTSUPP) || errors.Is(e.Err, unix.ENODEV)
+	case os.ErrPermission:
+		// The caller lacks permission to perform an operation.
+		return errors.Is(e.Err, unix.EPERM)
+	default:
+		return false
+	}
+}
+
+// parseLinkInfo parses LinkInfo structures from a slice of generic netlink
+// messages.
+func parseLinkInfo(msgs []genetlink.Message) ([]*LinkInfo, error) {
+	lis := make([]*LinkInfo, 0, len(msgs))
+	for _, m := range msgs {
+		ad, err := netlink.NewAttributeDecoder(m.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		var li LinkInfo
+		for ad.Next() {
+			switch ad.Type() {
+			case unix.ETHTOOL_A_LINKINFO_HEADER:
+				ad.Nested(parseInterface(&li.Interface))
+			case unix.ETHTOOL_A_LINKINFO_PORT:
+				li.Port = Port(ad.Uint8())
+			}
+		}
+
+		if err := ad.Err(); err != nil {
+			return nil, err
+		}
+
+		lis = append(lis, &li)
+	}
+
+	return lis, nil
+}
+
+// parseLinkModes parses LinkMode structures from a slice of generic netlink
+// messages.
+func parseLinkModes(msgs []genetlink.Message) ([]*LinkMode, error) {
+	lms := make([]*LinkMode, 0, len(msgs))
+	for _, m := range msgs {
+		ad, err := netlink.NewAttributeDecoder(m.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		var lm LinkMode
+		for ad.Next() {
+			switch ad.Type() {
+			case unix.ETHTOOL_A_LINKMODES_HEADER:
+				ad.Nested(parseInterface(&lm.Interface))
+			case unix.ETHTOOL_A_LINKMODES_OURS:
+				ad.Nested(parseAdvertisedLinkModes(&lm.Ours))
+			case unix.ETHTOOL_A_LINKMODES_PEER:
+				ad.Nested(parseAdvertisedLinkModes(&lm.Peer))
+			case unix.ETHTOOL_A_LINKMODES_SPEED:
+				lm.SpeedMegabits = int(ad.Uint32())
+			case unix.ETHTOOL_A_LINKMODES_DUPLEX:
+				lm.Duplex = Duplex(ad.Uint8())
+			case unix.ETHTOOL_A_LINKMODES_AUTONEG:
+				lm.Autoneg = Autoneg(ad.Uint8())
+			}
+		}
+
+		if err := ad.Err(); err != nil {
+			return nil, err
+		}
+
+		lms = append(lms, &lm)
+	}
+
+	return lms, nil
+}
+
+// parseAdvertisedLinkModes decodes an ethtool compact bitset into the input
+// slice of AdvertisedLinkModes.
+func parseAdvertisedLinkModes(alms *[]AdvertisedLinkMode) func(*netlink.AttributeDecoder) error {
+	return func(ad *netlink.AttributeDecoder) error {
+		values, err := newBitset(ad)
+		if err != nil {
+			return err
+		}
+
+		for i, v := range values {
+			if v == 0 {
+				// No bits set, don't bother checking.
+				continue
+			}
+
+			// Test each bit to find which ones are set, and use that to look up
+			// the proper index in linkModes (accounting for the offset of 32
+			// for each value in the array) so we can find the correct link mode
+			// to attach. Note that the lookup assumes that there will never be
+			// any skipped bits in the linkModes table.
+			//
+			// Thanks 0x0f10, c_h_lunde, TheCi, and Wacholderbaer from Twitch
+			// chat for saving me from myself!
+			for j := 0; j < 32; j++ {
+				if v&(1<<j) != 0 {
+					m := linkModes[(32*i)+j]
+					*alms = append(*alms, AdvertisedLinkMode{
+						Index: int(m.bit),
+						Name:  m.str,
+					})
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// parseLinkState parses LinkState structures from a slice of generic netlink
+// messages.
+func parseLinkState(msgs []genetlink.Message) ([]*LinkState, error) {
+	lss := make([]*LinkState, 0, len(msgs))
+	for _, m := range msgs {
+		ad, err := netlink.NewAttributeDecoder(m.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		var ls LinkState
+		for ad.Next() {
+			// TODO(mdlayher): try this out on fancier NICs to parse more of the
+			// extended state information.
+			switch ad.Type() {
+			case unix.ETHTOOL_A_LINKSTATE_HEADER:
+				ad.Nested(parseInterface(&ls.Interface))
+			case unix.ETHTOOL_A_LINKSTATE_LINK:
+				// Up/down is reported as a uint8 boolean.
+				ls.Link = ad.Uint8() != 0
+			}
+		}
+
+		if err := ad.Err(); err != nil {
+			return nil, err
+		}
+
+		lss = append(lss, &ls)
 	}
 
 	return lss, nil
@@ -830,6 +3748,24 @@ const (
 	_ETHTOOL_A_FEC_STATS         //nolint:revive
 )
 
+const (
+	_ETHTOOL_A_FEC_STAT_UNSPEC    = iota //nolint:revive
+	_ETHTOOL_A_FEC_STAT_PAD              //nolint:revive
+	_ETHTOOL_A_FEC_STAT_CORRECTED        //nolint:revive
+	_ETHTOOL_A_FEC_STAT_UNCORR           //nolint:revive
+	_ETHTOOL_A_FEC_STAT_CORR_BITS        //nolint:revive
+	_ETHTOOL_A_FEC_STAT_HIST             //nolint:revive
+)
+
+const (
+	_ETHTOOL_A_FEC_HIST_UNSPEC           = iota //nolint:revive
+	_ETHTOOL_A_FEC_HIST_PAD                     //nolint:revive
+	_ETHTOOL_A_FEC_HIST_BIN_LOW                 //nolint:revive
+	_ETHTOOL_A_FEC_HIST_BIN_HIGH                //nolint:revive
+	_ETHTOOL_A_FEC_HIST_BIN_VAL                 //nolint:revive
+	_ETHTOOL_A_FEC_HIST_BIN_VAL_PER_LANE        //nolint:revive
+)
+
 // parseFEC parses FEC structures from a slice of generic netlink
 // messages.
 func parseFEC(msgs []genetlink.Message) ([]*FEC, error) {
@@ -865,6 +3801,9 @@ func parseFEC(msgs []genetlink.Message) ([]*FEC, error) {
 				default:
 					return nil, fmt.Errorf("unsupported FEC link mode bit: %d", b)
 				}
+			case _ETHTOOL_A_FEC_STATS:
+				fec.Stats = new(FECStats)
+				ad.Nested(parseFECStats(fec.Stats))
 			}
 		}
 
@@ -878,6 +3817,81 @@ func parseFEC(msgs []genetlink.Message) ([]*FEC, error) {
 	return fecs, nil
 }
 
+// parseFECStats parses FEC statistics into a FECStats structure.
+func parseFECStats(fs *FECStats) func(*netlink.AttributeDecoder) error {
+	return func(ad *netlink.AttributeDecoder) error {
+		for ad.Next() {
+			switch ad.Type() {
+			case _ETHTOOL_A_FEC_STAT_CORRECTED:
+				ad.Do(fs.Corrected.decode)
+			case _ETHTOOL_A_FEC_STAT_UNCORR:
+				ad.Do(fs.Uncorrectable.decode)
+			case _ETHTOOL_A_FEC_STAT_CORR_BITS:
+				ad.Do(fs.CorrectedBits.decode)
+			case _ETHTOOL_A_FEC_STAT_HIST:
+				ad.Nested(func(nad *netlink.AttributeDecoder) error {
+					var bin FECHistogramBin
+					for nad.Next() {
+						switch nad.Type() {
+						case _ETHTOOL_A_FEC_HIST_BIN_LOW:
+							bin.Low = int(nad.Uint32())
+						case _ETHTOOL_A_FEC_HIST_BIN_HIGH:
+							bin.High = int(nad.Uint32())
+						case _ETHTOOL_A_FEC_HIST_BIN_VAL:
+							decodeUint(nad, &bin.Count)
+						case _ETHTOOL_A_FEC_HIST_BIN_VAL_PER_LANE:
+							nad.Do(func(b []byte) (err error) {
+								bin.Lanes, err = decodeUint64s(b)
+								return err
+							})
+						}
+					}
+
+					fs.Histogram = append(fs.Histogram, bin)
+					return nad.Err()
+				})
+			}
+		}
+
+		return nil
+	}
+}
+
+// decode unpacks a FEC statistics array, which contains the total followed by
+// the value for each lane if the device reports per-lane values.
+func (fc *FECCounter) decode(b []byte) error {
+	vs, err := decodeUint64s(b)
+	if err != nil {
+		return err
+	}
+
+	if len(vs) == 0 {
+		// Not reported by the device.
+		return nil
+	}
+
+	fc.Total = vs[0]
+	if len(vs) > 1 {
+		fc.Lanes = vs[1:]
+	}
+
+	return nil
+}
+
+// decodeUint64s unpacks an array of native endian uint64 values.
+func decodeUint64s(b []byte) ([]uint64, error) {
+	if len(b)%8 != 0 {
+		return nil, fmt.Errorf("ethtool: invalid uint64 array length: %d", len(b))
+	}
+
+	vs := make([]uint64, 0, len(b)/8)
+	for i := 0; i < len(b); i += 8 {
+		vs = append(vs, binary.NativeEndian.Uint64(b[i:i+8]))
+	}
+
+	return vs, nil
+}
+
 // parseFECModes decodes an ethtool compact bitset into the input FECModes.
 func parseFECModes(m *FECModes) func(*netlink.AttributeDecoder) error {
 	return func(ad *netlink.AttributeDecoder) error {
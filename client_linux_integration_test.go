@@ -4,9 +4,11 @@
 package ethtool_test
 
 import (
+	"context"
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/mdlayher/ethtool"
@@ -75,3 +77,33 @@ func TestIntegrationClientNetlinkStrict(t *testing.T) {
 		t.Fatalf("unexpected *ethtool.Error (-want +got):\n%s", diff)
 	}
 }
+
+func TestIntegrationClientMonitor(t *testing.T) {
+	c, err := ethtool.New()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			t.Skip("skipping, ethtool genetlink not found")
+		}
+
+		t.Fatalf("failed to open client: %v", err)
+	}
+	defer c.Close()
+
+	// Joining the monitor group does not require elevated privileges, but
+	// there's no guarantee that any settings will change while we listen, so
+	// just make sure the subscription is torn down once the deadline expires.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	for ev, err := range c.Monitor(ctx) {
+		if err != nil {
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("failed to monitor: %v", err)
+			}
+
+			break
+		}
+
+		t.Logf("event: %#v", ev)
+	}
+}
@@ -4,7 +4,15 @@
 package ethtool
 
 import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"math/big"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -80,6 +88,20 @@ func TestLinuxClientErrors(t *testing.T) {
 				return err
 			},
 		},
+		{
+			name: "features",
+			call: func(c *Client, ifi Interface) error {
+				_, err := c.Features(ifi)
+				return err
+			},
+		},
+		{
+			name: "rings",
+			call: func(c *Client, ifi Interface) error {
+				_, err := c.Rings(ifi)
+				return err
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -700,138 +722,3155 @@ func TestLinuxClientSetWakeOnLAN(t *testing.T) {
 	}
 }
 
-func requestHeader(typ uint16) func(*netlink.AttributeEncoder) {
-	return func(ae *netlink.AttributeEncoder) {
-		ae.Nested(typ, func(nae *netlink.AttributeEncoder) error {
-			headerFlags(nae)
-			return nil
-		})
+func TestLinuxClientMonitor(t *testing.T) {
+	skipBigEndian(t)
+
+	// Notifications are sent with the appropriate command and the same
+	// attributes as the corresponding get reply.
+	ntf := func(cmd uint8, m genetlink.Message) genetlink.Message {
+		m.Header.Command = cmd
+		return m
 	}
-}
 
-func requestIndex(typ uint16, compactBitsets bool) func(*netlink.AttributeEncoder) {
-	return func(ae *netlink.AttributeEncoder) {
-		ae.Nested(typ, func(nae *netlink.AttributeEncoder) error {
-			nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, 1)
-			if compactBitsets {
-				headerFlags(nae)
-			}
-			return nil
-		})
+	lm := &LinkMode{
+		Interface: Interface{
+			Index: 1,
+			Name:  "eth0",
+		},
+		SpeedMegabits: 10000,
+		Ours: []AdvertisedLinkMode{{
+			Index: unix.ETHTOOL_LINK_MODE_10000baseT_Full_BIT,
+			Name:  "10000baseT/Full",
+		}},
+		Duplex:  Full,
+		Autoneg: AutonegOn,
 	}
-}
 
-func requestName(typ uint16) func(*netlink.AttributeEncoder) {
-	return func(ae *netlink.AttributeEncoder) {
-		ae.Nested(typ, func(nae *netlink.AttributeEncoder) error {
-			nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, "eth1")
-			headerFlags(nae)
-			return nil
-		})
+	li := &LinkInfo{
+		Interface: Interface{
+			Index: 2,
+			Name:  "eth1",
+		},
+		Port: Fibre,
 	}
-}
 
-func requestBoth(typ uint16, compactBitsets bool) func(*netlink.AttributeEncoder) {
-	return func(ae *netlink.AttributeEncoder) {
-		ae.Nested(typ, func(nae *netlink.AttributeEncoder) error {
-			nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, 2)
-			nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, "eth1")
-			if compactBitsets {
-				headerFlags(nae)
-			}
-			return nil
-		})
+	wol := &WakeOnLAN{
+		Interface: Interface{
+			Index: 1,
+			Name:  "eth0",
+		},
+		Modes: Magic,
 	}
-}
 
-func headerFlags(ae *netlink.AttributeEncoder) {
-	ae.Uint32(unix.ETHTOOL_A_HEADER_FLAGS, unix.ETHTOOL_FLAG_COMPACT_BITSETS)
+	mod := &Module{
+		Interface: Interface{
+			Index: 1,
+			Name:  "eth0",
+		},
+		PowerModePolicy: ModulePowerModePolicyHigh,
+		PowerMode:       ModulePowerModeHigh,
+	}
+
+	plca := &PLCAConfig{
+		Interface: Interface{
+			Index: 1,
+			Name:  "eth0",
+		},
+		Enabled:         true,
+		NodeID:          1,
+		NodeCount:       8,
+		TOTimerBitTimes: 32,
+	}
+
+	want := []Event{lm, li, wol, mod, plca}
+
+	c := baseClient(t, func(_ genetlink.Message, _ netlink.Message) ([]genetlink.Message, error) {
+		t.Fatal("unexpected request on client connection")
+		return nil, nil
+	})
+	defer c.Close()
+
+	c.c.dialMonitor = func() (*genetlink.Conn, error) {
+		return genltest.Dial(func(_ genetlink.Message, _ netlink.Message) ([]genetlink.Message, error) {
+			return []genetlink.Message{
+				ntf(unix.ETHTOOL_MSG_LINKMODES_NTF, encodeLinkMode(t, *lm)),
+				// Unknown notifications are skipped.
+				ntf(unix.ETHTOOL_MSG_DEBUG_NTF, genetlink.Message{}),
+				ntf(unix.ETHTOOL_MSG_LINKINFO_NTF, encodeLinkInfo(t, *li)),
+				ntf(unix.ETHTOOL_MSG_WOL_NTF, encodeWOL(t, *wol)),
+				ntf(unix.ETHTOOL_MSG_MODULE_NTF, encodeModule(t, *mod)),
+				ntf(unix.ETHTOOL_MSG_PLCA_NTF, encodePLCAConfig(t, *plca)),
+			}, nil
+		}), nil
+	}
+
+	var got []Event
+	for ev, err := range c.Monitor(context.Background()) {
+		if err != nil {
+			t.Fatalf("failed to receive event: %v", err)
+		}
+
+		got = append(got, ev)
+		if len(got) == len(want) {
+			break
+		}
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected events (-want +got):\n%s", diff)
+	}
 }
 
-func encodeLinkInfo(t *testing.T, li LinkInfo) genetlink.Message {
-	t.Helper()
+func TestLinuxClientMonitorCanceled(t *testing.T) {
+	c := baseClient(t, func(_ genetlink.Message, _ netlink.Message) ([]genetlink.Message, error) {
+		t.Fatal("unexpected request on client connection")
+		return nil, nil
+	})
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.c.dialMonitor = func() (*genetlink.Conn, error) {
+		return genltest.Dial(func(_ genetlink.Message, _ netlink.Message) ([]genetlink.Message, error) {
+			// Cancel on the first receive, the monitor should stop before
+			// receiving again.
+			cancel()
+			return nil, nil
+		}), nil
+	}
 
-	return genetlink.Message{
-		Data: encode(t, func(ae *netlink.AttributeEncoder) {
-			ae.Nested(unix.ETHTOOL_A_LINKINFO_HEADER, func(nae *netlink.AttributeEncoder) error {
-				nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(li.Interface.Index))
-				nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, li.Interface.Name)
-				return nil
-			})
+	var err error
+	for _, err = range c.Monitor(ctx) {
+		if err != nil {
+			break
+		}
+	}
 
-			ae.Uint8(unix.ETHTOOL_A_LINKINFO_PORT, uint8(li.Port))
-		}),
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled, but got: %v", err)
 	}
 }
 
-func encodeLinkMode(t *testing.T, lm LinkMode) genetlink.Message {
-	t.Helper()
+func TestLinuxClientFeatures(t *testing.T) {
+	want := &Features{
+		Interface: Interface{
+			Index: 1,
+			Name:  "eth0",
+		},
+		Hardware: map[string]bool{
+			"rx-gro-hw":           true,
+			"rx-lro":              true,
+			"tx-tcp-segmentation": true,
+		},
+		Wanted: map[string]bool{
+			"rx-gro":              true,
+			"tx-tcp-segmentation": true,
+		},
+		Active: map[string]bool{
+			"rx-gro":              true,
+			"tx-tcp-segmentation": true,
+		},
+		NoChange: map[string]bool{
+			"tx-lockless": true,
+		},
+	}
 
-	return genetlink.Message{
-		Data: encode(t, func(ae *netlink.AttributeEncoder) {
-			ae.Nested(unix.ETHTOOL_A_LINKMODES_HEADER, func(nae *netlink.AttributeEncoder) error {
-				nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(lm.Interface.Index))
-				nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, lm.Interface.Name)
-				return nil
-			})
+	c := testClient(t, clientTest{
+		HeaderFlags: netlink.Request,
+		Command:     unix.ETHTOOL_MSG_FEATURES_GET,
+		// Features use verbose bitsets so the names of each feature are
+		// available, and therefore the compact flag is not set.
+		Attributes: requestIndex(unix.ETHTOOL_A_FEATURES_HEADER, false),
 
-			ae.Uint32(unix.ETHTOOL_A_LINKMODES_SPEED, uint32(lm.SpeedMegabits))
+		Messages: []genetlink.Message{encodeFeatures(t, *want)},
+	})
 
-			packALMs := func(typ uint16, alms []AdvertisedLinkMode) {
-				ae.Nested(typ, func(nae *netlink.AttributeEncoder) error {
-					fn := packALMBitset(alms)
-					nae.Uint32(unix.ETHTOOL_A_BITSET_SIZE, uint32(len(linkModes)))
-					nae.Do(unix.ETHTOOL_A_BITSET_VALUE, fn)
-					nae.Do(unix.ETHTOOL_A_BITSET_MASK, fn)
-					return nil
+	f, err := c.Features(Interface{Index: 1})
+	if err != nil {
+		t.Fatalf("failed to get features: %v", err)
+	}
+
+	if diff := cmp.Diff(want, f); diff != "" {
+		t.Fatalf("unexpected features (-want +got):\n%s", diff)
+	}
+}
+
+func TestLinuxClientSetFeatures(t *testing.T) {
+	features := map[string]bool{
+		"rx-gro":              false,
+		"rx-lro":              true,
+		"tx-tcp-segmentation": false,
+	}
+
+	tests := []struct {
+		name       string
+		reply      func(ae *netlink.AttributeEncoder)
+		want       map[string]bool
+		nlErr, err error
+	}{
+		{
+			name:  "EPERM",
+			nlErr: genltest.Error(int(unix.EPERM)),
+			err:   os.ErrPermission,
+		},
+		{
+			name: "all applied",
+			reply: func(ae *netlink.AttributeEncoder) {
+				encodeNamedBitset(ae, unix.ETHTOOL_A_FEATURES_WANTED, nil)
+				encodeNamedBitset(ae, unix.ETHTOOL_A_FEATURES_ACTIVE, features)
+			},
+			want: map[string]bool{},
+		},
+		{
+			name: "rejected",
+			reply: func(ae *netlink.AttributeEncoder) {
+				// LRO could not be enabled, so the kernel reports the value
+				// that was requested for it.
+				encodeNamedBitset(ae, unix.ETHTOOL_A_FEATURES_WANTED, map[string]bool{
+					"rx-lro": true,
+				})
+				encodeNamedBitset(ae, unix.ETHTOOL_A_FEATURES_ACTIVE, map[string]bool{
+					"rx-gro":              false,
+					"tx-tcp-segmentation": false,
 				})
+			},
+			want: map[string]bool{"rx-lro": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var msgs []genetlink.Message
+			if tt.reply != nil {
+				msgs = []genetlink.Message{{
+					Data: encode(t, func(ae *netlink.AttributeEncoder) {
+						ae.Nested(unix.ETHTOOL_A_FEATURES_HEADER, func(nae *netlink.AttributeEncoder) error {
+							nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, 1)
+							nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, "eth0")
+							return nil
+						})
+						tt.reply(ae)
+					}),
+				}}
 			}
 
-			packALMs(unix.ETHTOOL_A_LINKMODES_OURS, lm.Ours)
-			packALMs(unix.ETHTOOL_A_LINKMODES_PEER, lm.Peer)
+			c := testClient(t, clientTest{
+				// No acknowledgement, the kernel sends a reply instead.
+				HeaderFlags: netlink.Request,
+				Command:     unix.ETHTOOL_MSG_FEATURES_SET,
+				Attributes: func(ae *netlink.AttributeEncoder) {
+					requestIndex(unix.ETHTOOL_A_FEATURES_HEADER, false)(ae)
+					encodeNamedBitset(ae, unix.ETHTOOL_A_FEATURES_WANTED, features)
+				},
 
-			ae.Uint8(unix.ETHTOOL_A_LINKMODES_DUPLEX, uint8(lm.Duplex))
-			ae.Uint8(unix.ETHTOOL_A_LINKMODES_AUTONEG, uint8(lm.Autoneg))
-		}),
+				Messages: msgs,
+				Error:    tt.nlErr,
+			})
+
+			got, err := c.SetFeatures(Interface{Index: 1}, features)
+			if diff := cmp.Diff(tt.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected error (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("unexpected rejected features (-want +got):\n%s", diff)
+			}
+		})
 	}
 }
 
-func encodeLinkState(t *testing.T, ls LinkState) genetlink.Message {
-	t.Helper()
+func TestLinuxClientStringSets(t *testing.T) {
+	wolModes := &StringSet{
+		ID: StringSetWOLModes,
+		Strings: []string{
+			"phy", "ucast", "mcast", "bcast",
+			"arp", "magic", "magicsecure", "filter",
+		},
+	}
 
-	return genetlink.Message{
-		Data: encode(t, func(ae *netlink.AttributeEncoder) {
-			ae.Nested(unix.ETHTOOL_A_LINKSTATE_HEADER, func(nae *netlink.AttributeEncoder) error {
-				nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(ls.Interface.Index))
-				nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, ls.Interface.Name)
-				return nil
+	privFlags := &StringSet{
+		ID:      StringSetPrivateFlags,
+		Strings: []string{"legacy-rx", "disable-fw-lldp"},
+	}
+
+	tests := []struct {
+		name  string
+		ifi   Interface
+		ids   []StringSetID
+		attrs func(ae *netlink.AttributeEncoder)
+		sets  []*StringSet
+	}{
+		{
+			name:  "global",
+			attrs: requestHeader(unix.ETHTOOL_A_STRSET_HEADER),
+			sets:  []*StringSet{wolModes},
+		},
+		{
+			name: "device",
+			ifi:  Interface{Index: 1},
+			ids:  []StringSetID{StringSetWOLModes, StringSetPrivateFlags},
+			attrs: func(ae *netlink.AttributeEncoder) {
+				requestIndex(unix.ETHTOOL_A_STRSET_HEADER, true)(ae)
+				ae.Nested(unix.ETHTOOL_A_STRSET_STRINGSETS, func(nae *netlink.AttributeEncoder) error {
+					for _, id := range []StringSetID{StringSetWOLModes, StringSetPrivateFlags} {
+						nae.Nested(unix.ETHTOOL_A_STRINGSETS_STRINGSET, func(nnae *netlink.AttributeEncoder) error {
+							nnae.Uint32(unix.ETHTOOL_A_STRINGSET_ID, uint32(id))
+							return nil
+						})
+					}
+					return nil
+				})
+			},
+			sets: []*StringSet{wolModes, privFlags},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testClient(t, clientTest{
+				HeaderFlags: netlink.Request,
+				Command:     unix.ETHTOOL_MSG_STRSET_GET,
+				Attributes:  tt.attrs,
+
+				Messages: []genetlink.Message{encodeStringSets(t, tt.sets)},
 			})
 
-			// uint8 boolean conversion.
-			var link uint8
-			if ls.Link {
-				link = 1
+			sets, err := c.StringSets(tt.ifi, tt.ids...)
+			if err != nil {
+				t.Fatalf("failed to get string sets: %v", err)
 			}
 
-			ae.Uint8(unix.ETHTOOL_A_LINKSTATE_LINK, link)
-		}),
+			if diff := cmp.Diff(tt.sets, sets); diff != "" {
+				t.Fatalf("unexpected string sets (-want +got):\n%s", diff)
+			}
+		})
 	}
 }
 
-func encodeWOL(t *testing.T, wol WakeOnLAN) genetlink.Message {
-	t.Helper()
+func TestLinuxClientAllRings(t *testing.T) {
+	want := []*Rings{
+		{
+			Interface: Interface{
+				Index: 1,
+				Name:  "eth0",
+			},
+			RXMax:        4096,
+			TXMax:        4096,
+			RX:           512,
+			TX:           512,
+			RXBufLen:     2048,
+			TCPDataSplit: TCPDataSplitEnabled,
+			CQESize:      64,
+			TXPush:       true,
+		},
+		{
+			Interface: Interface{
+				Index: 2,
+				Name:  "eth1",
+			},
+			RXMax:           8192,
+			RXMiniMax:       1024,
+			RXJumboMax:      2048,
+			TXMax:           8192,
+			RX:              8192,
+			RXMini:          512,
+			RXJumbo:         1024,
+			TX:              8192,
+			RXPush:          true,
+			TXPushBufLen:    96,
+			TXPushBufLenMax: 128,
+		},
+	}
 
-	return genetlink.Message{
-		Data: encode(t, func(ae *netlink.AttributeEncoder) {
-			ae.Nested(unix.ETHTOOL_A_WOL_HEADER, func(nae *netlink.AttributeEncoder) error {
-				nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(wol.Interface.Index))
-				nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, wol.Interface.Name)
-				return nil
-			})
+	var msgs []genetlink.Message
+	for _, r := range want {
+		msgs = append(msgs, encodeRings(t, *r))
+	}
 
-			wol.encode(ae)
-		}),
+	c := testClient(t, clientTest{
+		HeaderFlags: netlink.Request | netlink.Dump,
+		Command:     unix.ETHTOOL_MSG_RINGS_GET,
+		Attributes:  requestHeader(unix.ETHTOOL_A_RINGS_HEADER),
+
+		Messages: msgs,
+	})
+
+	rs, err := c.AllRings()
+	if err != nil {
+		t.Fatalf("failed to get rings: %v", err)
+	}
+
+	if diff := cmp.Diff(want, rs); diff != "" {
+		t.Fatalf("unexpected rings (-want +got):\n%s", diff)
+	}
+}
+
+func TestLinuxClientSetRings(t *testing.T) {
+	var (
+		rx    = 4096
+		split = TCPDataSplitDisabled
+		push  = false
+	)
+
+	tests := []struct {
+		name       string
+		ru         *RingsUpdate
+		attrs      func(ae *netlink.AttributeEncoder)
+		nlErr, err error
+	}{
+		{
+			name:  "EPERM",
+			ru:    &RingsUpdate{},
+			attrs: requestIndex(unix.ETHTOOL_A_RINGS_HEADER, true),
+			nlErr: genltest.Error(int(unix.EPERM)),
+			err:   os.ErrPermission,
+		},
+		{
+			name:  "no changes",
+			ru:    &RingsUpdate{},
+			attrs: requestIndex(unix.ETHTOOL_A_RINGS_HEADER, true),
+		},
+		{
+			name: "partial",
+			ru: &RingsUpdate{
+				RX:           &rx,
+				TCPDataSplit: &split,
+				TXPush:       &push,
+			},
+			attrs: func(ae *netlink.AttributeEncoder) {
+				requestIndex(unix.ETHTOOL_A_RINGS_HEADER, true)(ae)
+				ae.Uint32(unix.ETHTOOL_A_RINGS_RX, 4096)
+				ae.Uint8(unix.ETHTOOL_A_RINGS_TCP_DATA_SPLIT, uint8(TCPDataSplitDisabled))
+				ae.Uint8(unix.ETHTOOL_A_RINGS_TX_PUSH, 0)
+			},
+		},
 	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testClient(t, clientTest{
+				HeaderFlags: netlink.Request | netlink.Acknowledge,
+				Command:     unix.ETHTOOL_MSG_RINGS_SET,
+				Attributes:  tt.attrs,
+
+				Messages: []genetlink.Message{{}},
+				Error:    tt.nlErr,
+			})
+
+			err := c.SetRings(Interface{Index: 1}, tt.ru)
+			if diff := cmp.Diff(tt.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected error (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLinuxClientAllChannels(t *testing.T) {
+	want := []*Channels{
+		{
+			Interface: Interface{
+				Index: 1,
+				Name:  "eth0",
+			},
+			CombinedMax: 8,
+			Combined:    4,
+		},
+		{
+			Interface: Interface{
+				Index: 2,
+				Name:  "eth1",
+			},
+			RXMax:    16,
+			TXMax:    16,
+			OtherMax: 1,
+			RX:       8,
+			TX:       8,
+			Other:    1,
+		},
+	}
+
+	var msgs []genetlink.Message
+	for _, ch := range want {
+		msgs = append(msgs, encodeChannels(t, *ch))
+	}
+
+	c := testClient(t, clientTest{
+		HeaderFlags: netlink.Request | netlink.Dump,
+		Command:     unix.ETHTOOL_MSG_CHANNELS_GET,
+		Attributes:  requestHeader(unix.ETHTOOL_A_CHANNELS_HEADER),
+
+		Messages: msgs,
+	})
+
+	chs, err := c.AllChannels()
+	if err != nil {
+		t.Fatalf("failed to get channels: %v", err)
+	}
+
+	if diff := cmp.Diff(want, chs); diff != "" {
+		t.Fatalf("unexpected channels (-want +got):\n%s", diff)
+	}
+}
+
+func TestLinuxClientSetChannels(t *testing.T) {
+	var (
+		combined = 4
+		tx       = 2
+	)
+
+	tests := []struct {
+		name       string
+		cu         *ChannelsUpdate
+		attrs      func(ae *netlink.AttributeEncoder)
+		nlErr, err error
+	}{
+		{
+			name:  "EPERM",
+			cu:    &ChannelsUpdate{},
+			attrs: requestIndex(unix.ETHTOOL_A_CHANNELS_HEADER, true),
+			nlErr: genltest.Error(int(unix.EPERM)),
+			err:   os.ErrPermission,
+		},
+		{
+			name: "OK",
+			cu: &ChannelsUpdate{
+				TX:       &tx,
+				Combined: &combined,
+			},
+			attrs: func(ae *netlink.AttributeEncoder) {
+				requestIndex(unix.ETHTOOL_A_CHANNELS_HEADER, true)(ae)
+				ae.Uint32(unix.ETHTOOL_A_CHANNELS_TX_COUNT, 2)
+				ae.Uint32(unix.ETHTOOL_A_CHANNELS_COMBINED_COUNT, 4)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testClient(t, clientTest{
+				HeaderFlags: netlink.Request | netlink.Acknowledge,
+				Command:     unix.ETHTOOL_MSG_CHANNELS_SET,
+				Attributes:  tt.attrs,
+
+				Messages: []genetlink.Message{{}},
+				Error:    tt.nlErr,
+			})
+
+			err := c.SetChannels(Interface{Index: 1}, tt.cu)
+			if diff := cmp.Diff(tt.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected error (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLinuxClientSetChannelsEINVAL(t *testing.T) {
+	var (
+		tooMany = 16
+		none    = 0
+	)
+
+	tests := []struct {
+		name    string
+		cu      *ChannelsUpdate
+		message string
+	}{
+		{
+			name:    "out of range",
+			cu:      &ChannelsUpdate{Combined: &tooMany},
+			message: "requested combined channel count 16 is out of range, maximum is 8",
+		},
+		{
+			name: "in range",
+			cu:   &ChannelsUpdate{Combined: &none},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := baseClient(t, func(greq genetlink.Message, _ netlink.Message) ([]genetlink.Message, error) {
+				switch greq.Header.Command {
+				case unix.ETHTOOL_MSG_CHANNELS_SET:
+					return nil, genltest.Error(int(unix.EINVAL))
+				case unix.ETHTOOL_MSG_CHANNELS_GET:
+					return []genetlink.Message{encodeChannels(t, Channels{
+						Interface:   Interface{Index: 1, Name: "eth0"},
+						CombinedMax: 8,
+						Combined:    4,
+					})}, nil
+				default:
+					t.Fatalf("unexpected command: %d", greq.Header.Command)
+					return nil, nil
+				}
+			})
+			defer c.Close()
+
+			err := c.SetChannels(Interface{Index: 1}, tt.cu)
+			if !errors.Is(err, unix.EINVAL) {
+				t.Fatalf("expected EINVAL, but got: %v", err)
+			}
+
+			var eerr *Error
+			if !errors.As(err, &eerr) {
+				t.Fatalf("expected *Error, but got: %T", err)
+			}
+
+			// The kernel's message is preserved, and the range information
+			// is only added to the wrapped error.
+			if eerr.Message != "" {
+				t.Fatalf("unexpected kernel error message: %q", eerr.Message)
+			}
+			if got := err.Error(); !strings.Contains(got, tt.message) {
+				t.Fatalf("expected error to contain %q, but got: %q", tt.message, got)
+			}
+		})
+	}
+}
+
+func TestLinuxClientAllCoalesce(t *testing.T) {
+	want := []*Coalesce{
+		{
+			Interface: Interface{
+				Index: 1,
+				Name:  "eth0",
+			},
+			RXUsecs:                   50,
+			RXMaxFrames:               64,
+			TXUsecs:                   100,
+			TXMaxFrames:               128,
+			AdaptiveRX:                true,
+			PacketRateLow:             1000,
+			RXUsecsLow:                10,
+			PacketRateHigh:            100000,
+			RXUsecsHigh:               200,
+			RateSampleIntervalSeconds: 2,
+			CQEModeRX:                 true,
+			TXAggrMaxBytes:            16384,
+			TXAggrMaxFrames:           8,
+			TXAggrTimeUsecs:           25,
+		},
+		{
+			Interface: Interface{
+				Index: 2,
+				Name:  "eth1",
+			},
+			RXUsecsIRQ:      5,
+			RXMaxFramesIRQ:  1,
+			TXUsecsIRQ:      5,
+			TXMaxFramesIRQ:  1,
+			StatsBlockUsecs: 1000000,
+			AdaptiveTX:      true,
+			CQEModeTX:       true,
+		},
+	}
+
+	var msgs []genetlink.Message
+	for _, c := range want {
+		msgs = append(msgs, encodeCoalesce(t, *c))
+	}
+
+	c := testClient(t, clientTest{
+		HeaderFlags: netlink.Request | netlink.Dump,
+		Command:     unix.ETHTOOL_MSG_COALESCE_GET,
+		Attributes:  requestHeader(unix.ETHTOOL_A_COALESCE_HEADER),
+
+		Messages: msgs,
+	})
+
+	cs, err := c.AllCoalesce()
+	if err != nil {
+		t.Fatalf("failed to get coalesce: %v", err)
+	}
+
+	if diff := cmp.Diff(want, cs); diff != "" {
+		t.Fatalf("unexpected coalesce (-want +got):\n%s", diff)
+	}
+}
+
+func TestLinuxClientSetCoalesce(t *testing.T) {
+	var (
+		adaptive = false
+		usecs    = 8
+		bytes    = 4096
+	)
+
+	tests := []struct {
+		name       string
+		cu         *CoalesceUpdate
+		attrs      func(ae *netlink.AttributeEncoder)
+		nlErr, err error
+	}{
+		{
+			name:  "EPERM",
+			cu:    &CoalesceUpdate{},
+			attrs: requestIndex(unix.ETHTOOL_A_COALESCE_HEADER, true),
+			nlErr: genltest.Error(int(unix.EPERM)),
+			err:   os.ErrPermission,
+		},
+		{
+			name:  "no changes",
+			cu:    &CoalesceUpdate{},
+			attrs: requestIndex(unix.ETHTOOL_A_COALESCE_HEADER, true),
+		},
+		{
+			name: "fixed RX",
+			cu: &CoalesceUpdate{
+				RXUsecs:        &usecs,
+				AdaptiveRX:     &adaptive,
+				TXAggrMaxBytes: &bytes,
+			},
+			attrs: func(ae *netlink.AttributeEncoder) {
+				requestIndex(unix.ETHTOOL_A_COALESCE_HEADER, true)(ae)
+				ae.Uint32(unix.ETHTOOL_A_COALESCE_RX_USECS, 8)
+				ae.Uint8(unix.ETHTOOL_A_COALESCE_USE_ADAPTIVE_RX, 0)
+				ae.Uint32(_ETHTOOL_A_COALESCE_TX_AGGR_MAX_BYTES, 4096)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testClient(t, clientTest{
+				HeaderFlags: netlink.Request | netlink.Acknowledge,
+				Command:     unix.ETHTOOL_MSG_COALESCE_SET,
+				Attributes:  tt.attrs,
+
+				Messages: []genetlink.Message{{}},
+				Error:    tt.nlErr,
+			})
+
+			err := c.SetCoalesce(Interface{Index: 1}, tt.cu)
+			if diff := cmp.Diff(tt.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected error (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLinuxClientPause(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats bool
+		attrs func(ae *netlink.AttributeEncoder)
+		p     *Pause
+	}{
+		{
+			name:  "no stats",
+			attrs: requestIndex(unix.ETHTOOL_A_PAUSE_HEADER, true),
+			p: &Pause{
+				Interface: Interface{Index: 1, Name: "eth0"},
+				Autoneg:   true,
+				RX:        true,
+			},
+		},
+		{
+			name:  "stats",
+			stats: true,
+			attrs: func(ae *netlink.AttributeEncoder) {
+				ae.Nested(unix.ETHTOOL_A_PAUSE_HEADER, func(nae *netlink.AttributeEncoder) error {
+					nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, 1)
+					nae.Uint32(unix.ETHTOOL_A_HEADER_FLAGS,
+						unix.ETHTOOL_FLAG_STATS|unix.ETHTOOL_FLAG_COMPACT_BITSETS)
+					return nil
+				})
+			},
+			p: &Pause{
+				Interface: Interface{Index: 1, Name: "eth0"},
+				RX:        true,
+				TX:        true,
+				Stats: &PauseStats{
+					TXFrames: 10,
+					RXFrames: 20,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testClient(t, clientTest{
+				HeaderFlags: netlink.Request,
+				Command:     unix.ETHTOOL_MSG_PAUSE_GET,
+				Attributes:  tt.attrs,
+
+				Messages: []genetlink.Message{encodePause(t, *tt.p)},
+			})
+
+			get := c.Pause
+			if tt.stats {
+				get = c.PauseWithStats
+			}
+
+			p, err := get(Interface{Index: 1})
+			if err != nil {
+				t.Fatalf("failed to get pause: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.p, p); diff != "" {
+				t.Fatalf("unexpected pause (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLinuxClientSetPause(t *testing.T) {
+	var (
+		off = false
+		on  = true
+	)
+
+	tests := []struct {
+		name       string
+		pu         *PauseUpdate
+		attrs      func(ae *netlink.AttributeEncoder)
+		nlErr, err error
+	}{
+		{
+			name:  "EPERM",
+			pu:    &PauseUpdate{},
+			attrs: requestIndex(unix.ETHTOOL_A_PAUSE_HEADER, true),
+			nlErr: genltest.Error(int(unix.EPERM)),
+			err:   os.ErrPermission,
+		},
+		{
+			name: "OK",
+			pu: &PauseUpdate{
+				Autoneg: &off,
+				TX:      &on,
+			},
+			attrs: func(ae *netlink.AttributeEncoder) {
+				requestIndex(unix.ETHTOOL_A_PAUSE_HEADER, true)(ae)
+				ae.Uint8(unix.ETHTOOL_A_PAUSE_AUTONEG, 0)
+				ae.Uint8(unix.ETHTOOL_A_PAUSE_TX, 1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testClient(t, clientTest{
+				HeaderFlags: netlink.Request | netlink.Acknowledge,
+				Command:     unix.ETHTOOL_MSG_PAUSE_SET,
+				Attributes:  tt.attrs,
+
+				Messages: []genetlink.Message{{}},
+				Error:    tt.nlErr,
+			})
+
+			err := c.SetPause(Interface{Index: 1}, tt.pu)
+			if diff := cmp.Diff(tt.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected error (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLinuxClientAllEEE(t *testing.T) {
+	want := []*EEE{
+		{
+			Interface: Interface{
+				Index: 1,
+				Name:  "eth0",
+			},
+			Ours: []AdvertisedLinkMode{
+				{
+					Index: unix.ETHTOOL_LINK_MODE_100baseT_Full_BIT,
+					Name:  "100baseT/Full",
+				},
+				{
+					Index: unix.ETHTOOL_LINK_MODE_1000baseT_Full_BIT,
+					Name:  "1000baseT/Full",
+				},
+			},
+			Peer: []AdvertisedLinkMode{{
+				Index: unix.ETHTOOL_LINK_MODE_1000baseT_Full_BIT,
+				Name:  "1000baseT/Full",
+			}},
+			Active:          true,
+			Enabled:         true,
+			TXLPIEnabled:    true,
+			TXLPITimerUsecs: 17,
+		},
+		{
+			Interface: Interface{
+				Index: 2,
+				Name:  "eth1",
+			},
+		},
+	}
+
+	var msgs []genetlink.Message
+	for _, e := range want {
+		msgs = append(msgs, encodeEEE(t, *e))
+	}
+
+	c := testClient(t, clientTest{
+		HeaderFlags: netlink.Request | netlink.Dump,
+		Command:     unix.ETHTOOL_MSG_EEE_GET,
+		Attributes:  requestHeader(unix.ETHTOOL_A_EEE_HEADER),
+
+		Messages: msgs,
+	})
+
+	es, err := c.AllEEE()
+	if err != nil {
+		t.Fatalf("failed to get EEE: %v", err)
+	}
+
+	if diff := cmp.Diff(want, es); diff != "" {
+		t.Fatalf("unexpected EEE (-want +got):\n%s", diff)
+	}
+}
+
+func TestLinuxClientSetEEE(t *testing.T) {
+	skipBigEndian(t)
+
+	var (
+		off   = false
+		timer = 100
+	)
+
+	tests := []struct {
+		name       string
+		eu         *EEEUpdate
+		attrs      func(ae *netlink.AttributeEncoder)
+		nlErr, err error
+	}{
+		{
+			name:  "EPERM",
+			eu:    &EEEUpdate{},
+			attrs: requestIndex(unix.ETHTOOL_A_EEE_HEADER, true),
+			nlErr: genltest.Error(int(unix.EPERM)),
+			err:   os.ErrPermission,
+		},
+		{
+			name: "disable",
+			eu:   &EEEUpdate{Enabled: &off},
+			attrs: func(ae *netlink.AttributeEncoder) {
+				requestIndex(unix.ETHTOOL_A_EEE_HEADER, true)(ae)
+				ae.Uint8(unix.ETHTOOL_A_EEE_ENABLED, 0)
+			},
+		},
+		{
+			name: "advertise",
+			eu: &EEEUpdate{
+				Advertise:       big.NewInt(1<<unix.ETHTOOL_LINK_MODE_100baseT_Full_BIT | 1<<unix.ETHTOOL_LINK_MODE_1000baseT_Full_BIT),
+				TXLPITimerUsecs: &timer,
+			},
+			attrs: func(ae *netlink.AttributeEncoder) {
+				requestIndex(unix.ETHTOOL_A_EEE_HEADER, true)(ae)
+				ae.Nested(unix.ETHTOOL_A_EEE_MODES_OURS, func(nae *netlink.AttributeEncoder) error {
+					nae.Flag(unix.ETHTOOL_A_BITSET_NOMASK, true)
+					nae.Uint32(unix.ETHTOOL_A_BITSET_SIZE, 6)
+					nae.Bytes(unix.ETHTOOL_A_BITSET_VALUE, []byte{0x28, 0x00, 0x00, 0x00})
+					return nil
+				})
+				ae.Uint32(unix.ETHTOOL_A_EEE_TX_LPI_TIMER, 100)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testClient(t, clientTest{
+				HeaderFlags: netlink.Request | netlink.Acknowledge,
+				Command:     unix.ETHTOOL_MSG_EEE_SET,
+				Attributes:  tt.attrs,
+
+				Messages: []genetlink.Message{{}},
+				Error:    tt.nlErr,
+			})
+
+			err := c.SetEEE(Interface{Index: 1}, tt.eu)
+			if diff := cmp.Diff(tt.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected error (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLinuxClientModule(t *testing.T) {
+	want := &Module{
+		Interface:       Interface{Index: 1, Name: "eth0"},
+		PowerModePolicy: ModulePowerModePolicyAuto,
+		PowerMode:       ModulePowerModeLow,
+	}
+
+	c := testClient(t, clientTest{
+		HeaderFlags: netlink.Request,
+		Command:     unix.ETHTOOL_MSG_MODULE_GET,
+		Attributes:  requestIndex(_ETHTOOL_A_MODULE_HEADER, true),
+
+		Messages: []genetlink.Message{encodeModule(t, *want)},
+	})
+
+	m, err := c.Module(Interface{Index: 1})
+	if err != nil {
+		t.Fatalf("failed to get module: %v", err)
+	}
+
+	if diff := cmp.Diff(want, m); diff != "" {
+		t.Fatalf("unexpected module (-want +got):\n%s", diff)
+	}
+}
+
+func TestLinuxClientSetModule(t *testing.T) {
+	high := ModulePowerModePolicyHigh
+
+	tests := []struct {
+		name       string
+		mu         *ModuleUpdate
+		attrs      func(ae *netlink.AttributeEncoder)
+		nlErr, err error
+	}{
+		{
+			name:  "EPERM",
+			mu:    &ModuleUpdate{},
+			attrs: requestIndex(_ETHTOOL_A_MODULE_HEADER, true),
+			nlErr: genltest.Error(int(unix.EPERM)),
+			err:   os.ErrPermission,
+		},
+		{
+			name: "high",
+			mu:   &ModuleUpdate{PowerModePolicy: &high},
+			attrs: func(ae *netlink.AttributeEncoder) {
+				requestIndex(_ETHTOOL_A_MODULE_HEADER, true)(ae)
+				ae.Uint8(_ETHTOOL_A_MODULE_POWER_MODE_POLICY, 1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testClient(t, clientTest{
+				HeaderFlags: netlink.Request | netlink.Acknowledge,
+				Command:     unix.ETHTOOL_MSG_MODULE_SET,
+				Attributes:  tt.attrs,
+
+				Messages: []genetlink.Message{{}},
+				Error:    tt.nlErr,
+			})
+
+			err := c.SetModule(Interface{Index: 1}, tt.mu)
+			if diff := cmp.Diff(tt.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected error (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLinuxClientPSE(t *testing.T) {
+	tests := []struct {
+		name  string
+		attrs func(ae *netlink.AttributeEncoder)
+		want  *PSE
+	}{
+		{
+			name: "PoDL",
+			attrs: func(ae *netlink.AttributeEncoder) {
+				ae.Uint32(_ETHTOOL_A_PODL_PSE_ADMIN_STATE, uint32(PSEAdminStateEnabled))
+				ae.Uint32(_ETHTOOL_A_PODL_PSE_PW_D_STATUS, uint32(PoDLPSEPowerStatusDelivering))
+			},
+			want: &PSE{
+				Interface: Interface{Index: 1, Name: "eth0"},
+				PoDL: &PoDLPSE{
+					AdminState:  PSEAdminStateEnabled,
+					PowerStatus: PoDLPSEPowerStatusDelivering,
+				},
+			},
+		},
+		{
+			name: "C33",
+			attrs: func(ae *netlink.AttributeEncoder) {
+				ae.Uint32(_ETHTOOL_A_C33_PSE_ADMIN_STATE, uint32(PSEAdminStateEnabled))
+				ae.Uint32(_ETHTOOL_A_C33_PSE_PW_D_STATUS, uint32(C33PSEPowerStatusFault))
+				ae.Uint32(_ETHTOOL_A_C33_PSE_PW_CLASS, 4)
+				ae.Uint32(_ETHTOOL_A_C33_PSE_ACTUAL_PW, 0)
+				ae.Uint32(_ETHTOOL_A_C33_PSE_EXT_STATE, uint32(C33PSEExtStateOverloadDetected))
+				ae.Uint32(_ETHTOOL_A_C33_PSE_EXT_SUBSTATE, 1)
+				ae.Uint32(_ETHTOOL_A_C33_PSE_AVAIL_PW_LIMIT, 30000)
+				for _, r := range [][2]uint32{{15000, 15000}, {30000, 30000}} {
+					ae.Nested(_ETHTOOL_A_C33_PSE_PW_LIMIT_RANGES, func(nae *netlink.AttributeEncoder) error {
+						nae.Uint32(_ETHTOOL_A_C33_PSE_PW_LIMIT_MIN, r[0])
+						nae.Uint32(_ETHTOOL_A_C33_PSE_PW_LIMIT_MAX, r[1])
+						return nil
+					})
+				}
+				ae.Uint32(_ETHTOOL_A_PSE_PW_D_ID, 2)
+				ae.Uint32(_ETHTOOL_A_PSE_PRIO_MAX, 7)
+				ae.Uint32(_ETHTOOL_A_PSE_PRIO, 3)
+			},
+			want: &PSE{
+				Interface: Interface{Index: 1, Name: "eth0"},
+				C33: &C33PSE{
+					AdminState:           PSEAdminStateEnabled,
+					PowerStatus:          C33PSEPowerStatusFault,
+					PowerClass:           4,
+					ExtState:             C33PSEExtStateOverloadDetected,
+					ExtSubstate:          1,
+					PowerLimitMilliwatts: 30000,
+					PowerLimitRanges: []PSEPowerLimitRange{
+						{MinMilliwatts: 15000, MaxMilliwatts: 15000},
+						{MinMilliwatts: 30000, MaxMilliwatts: 30000},
+					},
+				},
+				PowerDomainID: 2,
+				Priority:      3,
+				PriorityMax:   7,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testClient(t, clientTest{
+				HeaderFlags: netlink.Request,
+				Command:     unix.ETHTOOL_MSG_PSE_GET,
+				Attributes:  requestIndex(_ETHTOOL_A_PSE_HEADER, true),
+
+				Messages: []genetlink.Message{{
+					Data: encode(t, func(ae *netlink.AttributeEncoder) {
+						ae.Nested(_ETHTOOL_A_PSE_HEADER, func(nae *netlink.AttributeEncoder) error {
+							nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, 1)
+							nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, "eth0")
+							return nil
+						})
+						tt.attrs(ae)
+					}),
+				}},
+			})
+
+			p, err := c.PSE(Interface{Index: 1})
+			if err != nil {
+				t.Fatalf("failed to get PSE: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, p); diff != "" {
+				t.Fatalf("unexpected PSE (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLinuxClientSetPSE(t *testing.T) {
+	var (
+		disabled = PSEAdminStateDisabled
+		limit    = 15000
+		prio     = 0
+	)
+
+	tests := []struct {
+		name       string
+		pu         *PSEUpdate
+		attrs      func(ae *netlink.AttributeEncoder)
+		nlErr, err error
+	}{
+		{
+			name:  "EPERM",
+			pu:    &PSEUpdate{},
+			attrs: requestIndex(_ETHTOOL_A_PSE_HEADER, true),
+			nlErr: genltest.Error(int(unix.EPERM)),
+			err:   os.ErrPermission,
+		},
+		{
+			name: "PoDL disable",
+			pu:   &PSEUpdate{PoDLAdminControl: &disabled},
+			attrs: func(ae *netlink.AttributeEncoder) {
+				requestIndex(_ETHTOOL_A_PSE_HEADER, true)(ae)
+				ae.Uint32(_ETHTOOL_A_PODL_PSE_ADMIN_CONTROL, 2)
+			},
+		},
+		{
+			name: "C33",
+			pu: &PSEUpdate{
+				C33AdminControl:         &disabled,
+				C33PowerLimitMilliwatts: &limit,
+				Priority:                &prio,
+			},
+			attrs: func(ae *netlink.AttributeEncoder) {
+				requestIndex(_ETHTOOL_A_PSE_HEADER, true)(ae)
+				ae.Uint32(_ETHTOOL_A_C33_PSE_ADMIN_CONTROL, 2)
+				ae.Uint32(_ETHTOOL_A_C33_PSE_AVAIL_PW_LIMIT, 15000)
+				ae.Uint32(_ETHTOOL_A_PSE_PRIO, 0)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testClient(t, clientTest{
+				HeaderFlags: netlink.Request | netlink.Acknowledge,
+				Command:     unix.ETHTOOL_MSG_PSE_SET,
+				Attributes:  tt.attrs,
+
+				Messages: []genetlink.Message{{}},
+				Error:    tt.nlErr,
+			})
+
+			err := c.SetPSE(Interface{Index: 1}, tt.pu)
+			if diff := cmp.Diff(tt.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected error (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLinuxClientTimestampInfo(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats bool
+		attrs func(ae *netlink.AttributeEncoder)
+		ti    *TimestampInfo
+	}{
+		{
+			name:  "software",
+			attrs: requestIndex(unix.ETHTOOL_A_TSINFO_HEADER, false),
+			ti: &TimestampInfo{
+				Interface: Interface{Index: 1, Name: "eth0"},
+				Timestamping: map[string]bool{
+					"software-transmit": true,
+					"software-receive":  true,
+				},
+				TXTypes:   map[string]bool{},
+				RXFilters: map[string]bool{},
+				PHCIndex:  -1,
+			},
+		},
+		{
+			name:  "hardware stats",
+			stats: true,
+			attrs: func(ae *netlink.AttributeEncoder) {
+				ae.Nested(unix.ETHTOOL_A_TSINFO_HEADER, func(nae *netlink.AttributeEncoder) error {
+					nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, 1)
+					nae.Uint32(unix.ETHTOOL_A_HEADER_FLAGS, unix.ETHTOOL_FLAG_STATS)
+					return nil
+				})
+			},
+			ti: &TimestampInfo{
+				Interface: Interface{Index: 1, Name: "eth0"},
+				Timestamping: map[string]bool{
+					"hardware-transmit": true,
+					"hardware-receive":  true,
+					"raw-hardware":      true,
+				},
+				TXTypes: map[string]bool{
+					"off": true,
+					"on":  true,
+				},
+				RXFilters: map[string]bool{
+					"none": true,
+					"all":  true,
+				},
+				PHCIndex: 0,
+				Stats: &TimestampStats{
+					TXPackets: 1 << 40,
+					TXLost:    2,
+					TXErrors:  1,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testClient(t, clientTest{
+				HeaderFlags: netlink.Request,
+				Command:     unix.ETHTOOL_MSG_TSINFO_GET,
+				Attributes:  tt.attrs,
+
+				Messages: []genetlink.Message{encodeTimestampInfo(t, *tt.ti)},
+			})
+
+			get := c.TimestampInfo
+			if tt.stats {
+				get = c.TimestampInfoWithStats
+			}
+
+			ti, err := get(Interface{Index: 1})
+			if err != nil {
+				t.Fatalf("failed to get timestamp info: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.ti, ti); diff != "" {
+				t.Fatalf("unexpected timestamp info (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLinuxClientTimestampInfoInvalidStats(t *testing.T) {
+	c := testClient(t, clientTest{
+		HeaderFlags: netlink.Request,
+		Command:     unix.ETHTOOL_MSG_TSINFO_GET,
+		Attributes: func(ae *netlink.AttributeEncoder) {
+			ae.Nested(unix.ETHTOOL_A_TSINFO_HEADER, func(nae *netlink.AttributeEncoder) error {
+				nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, 1)
+				nae.Uint32(unix.ETHTOOL_A_HEADER_FLAGS, unix.ETHTOOL_FLAG_STATS)
+				return nil
+			})
+		},
+
+		// Counters are packed as 32 or 64 bits, never 16.
+		Messages: []genetlink.Message{{
+			Data: encode(t, func(ae *netlink.AttributeEncoder) {
+				ae.Nested(unix.ETHTOOL_A_TSINFO_STATS, func(nae *netlink.AttributeEncoder) error {
+					nae.Uint16(_ETHTOOL_A_TS_STAT_TX_PKTS, 1)
+					return nil
+				})
+			}),
+		}},
+	})
+
+	_, err := c.TimestampInfoWithStats(Interface{Index: 1})
+	if err == nil {
+		t.Fatal("expected an error, but none occurred")
+	}
+}
+
+func TestLinuxClientCableTest(t *testing.T) {
+	want := &CableTestResult{
+		Interface: Interface{Index: 1, Name: "eth0"},
+		Pairs: []CableTestPair{
+			{
+				Pair:                   CablePairA,
+				Code:                   CableTestOK,
+				FaultLengthCentimeters: -1,
+			},
+			{
+				Pair:                   CablePairB,
+				Code:                   CableTestOpen,
+				FaultLengthCentimeters: 1250,
+			},
+		},
+	}
+
+	c := testClient(t, clientTest{
+		HeaderFlags: netlink.Request | netlink.Acknowledge,
+		Command:     unix.ETHTOOL_MSG_CABLE_TEST_ACT,
+		Attributes:  requestIndex(unix.ETHTOOL_A_CABLE_TEST_HEADER, true),
+
+		Messages: []genetlink.Message{{}},
+	})
+
+	c.c.dialMonitor = func() (*genetlink.Conn, error) {
+		return genltest.Dial(func(_ genetlink.Message, _ netlink.Message) ([]genetlink.Message, error) {
+			return []genetlink.Message{
+				encodeCableTest(t, unix.ETHTOOL_A_CABLE_TEST_NTF_STATUS_STARTED, &CableTestResult{
+					Interface: want.Interface,
+				}),
+				// Unrelated notifications and tests on other interfaces are
+				// skipped.
+				{Header: genetlink.Header{Command: unix.ETHTOOL_MSG_LINKINFO_NTF}},
+				encodeCableTest(t, unix.ETHTOOL_A_CABLE_TEST_NTF_STATUS_COMPLETED, &CableTestResult{
+					Interface: Interface{Index: 2, Name: "eth1"},
+					Pairs:     []CableTestPair{{Pair: CablePairA, Code: CableTestShort}},
+				}),
+				encodeCableTest(t, unix.ETHTOOL_A_CABLE_TEST_NTF_STATUS_COMPLETED, want),
+			}, nil
+		}), nil
+	}
+
+	got, err := c.CableTest(context.Background(), Interface{Index: 1})
+	if err != nil {
+		t.Fatalf("failed to run cable test: %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected cable test result (-want +got):\n%s", diff)
+	}
+}
+
+func TestLinuxClientCableTestErrors(t *testing.T) {
+	t.Run("EPERM", func(t *testing.T) {
+		c := testClient(t, clientTest{
+			Error: genltest.Error(int(unix.EPERM)),
+		})
+
+		c.c.dialMonitor = func() (*genetlink.Conn, error) {
+			return genltest.Dial(func(_ genetlink.Message, _ netlink.Message) ([]genetlink.Message, error) {
+				t.Fatal("unexpected receive on monitor connection")
+				return nil, nil
+			}), nil
+		}
+
+		_, err := c.CableTest(context.Background(), Interface{Index: 1})
+		if !errors.Is(err, os.ErrPermission) {
+			t.Fatalf("expected permission denied, but got: %v", err)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		c := testClient(t, clientTest{
+			HeaderFlags: netlink.Request | netlink.Acknowledge,
+			Command:     unix.ETHTOOL_MSG_CABLE_TEST_ACT,
+			Attributes:  requestIndex(unix.ETHTOOL_A_CABLE_TEST_HEADER, true),
+
+			Messages: []genetlink.Message{{}},
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		c.c.dialMonitor = func() (*genetlink.Conn, error) {
+			return genltest.Dial(func(_ genetlink.Message, _ netlink.Message) ([]genetlink.Message, error) {
+				// The test never completes, so give up after it starts.
+				cancel()
+				return []genetlink.Message{
+					encodeCableTest(t, unix.ETHTOOL_A_CABLE_TEST_NTF_STATUS_STARTED, &CableTestResult{
+						Interface: Interface{Index: 1, Name: "eth0"},
+					}),
+				}, nil
+			}), nil
+		}
+
+		_, err := c.CableTest(ctx, Interface{Index: 1})
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context canceled, but got: %v", err)
+		}
+	})
+}
+
+func TestLinuxClientCableTestTDR(t *testing.T) {
+	var (
+		first = 100
+		last  = 300
+		step  = 100
+		pair  = CablePairB
+	)
+
+	want := &CableTestTDRResult{
+		Interface:        Interface{Index: 1, Name: "eth0"},
+		PulsesMillivolts: []int{1000},
+		Steps: []CableTestTDRStep{{
+			FirstCentimeters: 100,
+			LastCentimeters:  200,
+			StepCentimeters:  100,
+		}},
+		Pairs: []CableTestTDRPair{
+			{
+				Pair: CablePairA,
+				Samples: []CableTestTDRSample{
+					{DistanceCentimeters: 100, AmplitudeMillivolts: 20},
+					{DistanceCentimeters: 200, AmplitudeMillivolts: -15},
+				},
+			},
+			{
+				Pair: CablePairB,
+				Samples: []CableTestTDRSample{
+					{DistanceCentimeters: 100, AmplitudeMillivolts: 5},
+					{DistanceCentimeters: 200, AmplitudeMillivolts: 600},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		cfg   *CableTestTDRConfig
+		attrs func(ae *netlink.AttributeEncoder)
+	}{
+		{
+			name:  "defaults",
+			attrs: requestIndex(unix.ETHTOOL_A_CABLE_TEST_TDR_HEADER, true),
+		},
+		{
+			name: "config",
+			cfg: &CableTestTDRConfig{
+				FirstCentimeters: &first,
+				LastCentimeters:  &last,
+				StepCentimeters:  &step,
+				Pair:             &pair,
+			},
+			attrs: func(ae *netlink.AttributeEncoder) {
+				requestIndex(unix.ETHTOOL_A_CABLE_TEST_TDR_HEADER, true)(ae)
+				ae.Nested(unix.ETHTOOL_A_CABLE_TEST_TDR_CFG, func(nae *netlink.AttributeEncoder) error {
+					nae.Uint32(unix.ETHTOOL_A_CABLE_TEST_TDR_CFG_FIRST, 100)
+					nae.Uint32(unix.ETHTOOL_A_CABLE_TEST_TDR_CFG_LAST, 300)
+					nae.Uint32(unix.ETHTOOL_A_CABLE_TEST_TDR_CFG_STEP, 100)
+					nae.Uint8(unix.ETHTOOL_A_CABLE_TEST_TDR_CFG_PAIR, uint8(CablePairB))
+					return nil
+				})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testClient(t, clientTest{
+				HeaderFlags: netlink.Request | netlink.Acknowledge,
+				Command:     unix.ETHTOOL_MSG_CABLE_TEST_TDR_ACT,
+				Attributes:  tt.attrs,
+
+				Messages: []genetlink.Message{{}},
+			})
+
+			c.c.dialMonitor = func() (*genetlink.Conn, error) {
+				return genltest.Dial(func(_ genetlink.Message, _ netlink.Message) ([]genetlink.Message, error) {
+					return []genetlink.Message{encodeCableTestTDR(t, want)}, nil
+				}), nil
+			}
+
+			got, err := c.CableTestTDR(context.Background(), Interface{Index: 1}, tt.cfg)
+			if err != nil {
+				t.Fatalf("failed to run TDR cable test: %v", err)
+			}
+
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Fatalf("unexpected TDR result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLinuxClientTunnelInfo(t *testing.T) {
+	skipBigEndian(t)
+
+	want := &TunnelInfo{
+		Interface: Interface{Index: 1, Name: "eth0"},
+		UDPTables: []UDPTunnelTable{
+			{
+				Size:  4,
+				Types: []UDPTunnelType{UDPTunnelVXLAN, UDPTunnelVXLANGPE},
+				Ports: []UDPTunnelPort{
+					{Port: 4789, Type: UDPTunnelVXLAN},
+					{Port: 8472, Type: UDPTunnelVXLAN},
+				},
+			},
+			{
+				Size:  1,
+				Types: []UDPTunnelType{UDPTunnelGENEVE},
+			},
+		},
+	}
+
+	c := testClient(t, clientTest{
+		HeaderFlags: netlink.Request,
+		Command:     unix.ETHTOOL_MSG_TUNNEL_INFO_GET,
+		Attributes:  requestIndex(unix.ETHTOOL_A_TUNNEL_INFO_HEADER, true),
+
+		Messages: []genetlink.Message{encodeTunnelInfo(t, *want)},
+	})
+
+	ti, err := c.TunnelInfo(Interface{Index: 1})
+	if err != nil {
+		t.Fatalf("failed to get tunnel info: %v", err)
+	}
+
+	if diff := cmp.Diff(want, ti); diff != "" {
+		t.Fatalf("unexpected tunnel info (-want +got):\n%s", diff)
+	}
+}
+
+func TestLinuxClientAllTunnelInfoFallback(t *testing.T) {
+	skipBigEndian(t)
+
+	want := []*TunnelInfo{{
+		Interface: Interface{Index: 1, Name: "eth0"},
+		UDPTables: []UDPTunnelTable{{
+			Size:  1,
+			Types: []UDPTunnelType{UDPTunnelVXLAN},
+			Ports: []UDPTunnelPort{{Port: 4789, Type: UDPTunnelVXLAN}},
+		}},
+	}}
+
+	c := baseClient(t, func(greq genetlink.Message, req netlink.Message) ([]genetlink.Message, error) {
+		switch greq.Header.Command {
+		case unix.ETHTOOL_MSG_LINKINFO_GET:
+			return []genetlink.Message{
+				encodeLinkInfo(t, LinkInfo{Interface: Interface{Index: 1, Name: "eth0"}}),
+				encodeLinkInfo(t, LinkInfo{Interface: Interface{Index: 2, Name: "eth1"}}),
+			}, nil
+		case unix.ETHTOOL_MSG_TUNNEL_INFO_GET:
+			// eth1 doesn't support tunnel offloads, which also causes the
+			// dump to fail.
+			if req.Header.Flags&netlink.Dump != 0 {
+				// genltest.Error echoes the dump flags, which the netlink
+				// package would interpret as an extended acknowledgement.
+				return nil, &netlink.OpError{Op: "receive", Err: unix.EOPNOTSUPP}
+			}
+			if !bytes.Contains(greq.Data, []byte("eth0")) {
+				return nil, genltest.Error(int(unix.EOPNOTSUPP))
+			}
+
+			return []genetlink.Message{encodeTunnelInfo(t, *want[0])}, nil
+		default:
+			t.Fatalf("unexpected command: %d", greq.Header.Command)
+			return nil, nil
+		}
+	})
+	defer c.Close()
+
+	tis, err := c.AllTunnelInfo()
+	if err != nil {
+		t.Fatalf("failed to get tunnel info: %v", err)
+	}
+
+	if diff := cmp.Diff(want, tis); diff != "" {
+		t.Fatalf("unexpected tunnel info (-want +got):\n%s", diff)
+	}
+}
+
+func TestLinuxClientFECWithStats(t *testing.T) {
+	skipBigEndian(t)
+
+	u64s := func(vs ...uint64) []byte {
+		var b []byte
+		for _, v := range vs {
+			b = binary.NativeEndian.AppendUint64(b, v)
+		}
+		return b
+	}
+
+	tests := []struct {
+		name  string
+		stats func(ae *netlink.AttributeEncoder)
+		want  *FECStats
+	}{
+		{
+			name: "totals",
+			stats: func(ae *netlink.AttributeEncoder) {
+				ae.Bytes(_ETHTOOL_A_FEC_STAT_CORRECTED, u64s(100))
+				ae.Bytes(_ETHTOOL_A_FEC_STAT_UNCORR, u64s(2))
+				// Not supported by the device.
+				ae.Bytes(_ETHTOOL_A_FEC_STAT_CORR_BITS, nil)
+			},
+			want: &FECStats{
+				Corrected:     FECCounter{Total: 100},
+				Uncorrectable: FECCounter{Total: 2},
+			},
+		},
+		{
+			name: "lanes and histogram",
+			stats: func(ae *netlink.AttributeEncoder) {
+				ae.Bytes(_ETHTOOL_A_FEC_STAT_CORRECTED, u64s(30, 10, 20))
+				ae.Bytes(_ETHTOOL_A_FEC_STAT_UNCORR, u64s(1, 0, 1))
+				ae.Bytes(_ETHTOOL_A_FEC_STAT_CORR_BITS, u64s(300, 100, 200))
+				ae.Nested(_ETHTOOL_A_FEC_STAT_HIST, func(nae *netlink.AttributeEncoder) error {
+					nae.Uint32(_ETHTOOL_A_FEC_HIST_BIN_LOW, 0)
+					nae.Uint32(_ETHTOOL_A_FEC_HIST_BIN_HIGH, 0)
+					nae.Uint64(_ETHTOOL_A_FEC_HIST_BIN_VAL, 1<<40)
+					return nil
+				})
+				ae.Nested(_ETHTOOL_A_FEC_STAT_HIST, func(nae *netlink.AttributeEncoder) error {
+					nae.Uint32(_ETHTOOL_A_FEC_HIST_BIN_LOW, 1)
+					nae.Uint32(_ETHTOOL_A_FEC_HIST_BIN_HIGH, 3)
+					nae.Uint32(_ETHTOOL_A_FEC_HIST_BIN_VAL, 30)
+					nae.Bytes(_ETHTOOL_A_FEC_HIST_BIN_VAL_PER_LANE, u64s(10, 20))
+					return nil
+				})
+			},
+			want: &FECStats{
+				Corrected:     FECCounter{Total: 30, Lanes: []uint64{10, 20}},
+				Uncorrectable: FECCounter{Total: 1, Lanes: []uint64{0, 1}},
+				CorrectedBits: FECCounter{Total: 300, Lanes: []uint64{100, 200}},
+				Histogram: []FECHistogramBin{
+					{Low: 0, High: 0, Count: 1 << 40},
+					{Low: 1, High: 3, Count: 30, Lanes: []uint64{10, 20}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testClient(t, clientTest{
+				HeaderFlags: netlink.Request,
+				Command:     unix.ETHTOOL_MSG_FEC_GET,
+				Attributes: func(ae *netlink.AttributeEncoder) {
+					ae.Nested(_ETHTOOL_A_FEC_HEADER, func(nae *netlink.AttributeEncoder) error {
+						nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, 1)
+						nae.Uint32(unix.ETHTOOL_A_HEADER_FLAGS,
+							unix.ETHTOOL_FLAG_STATS|unix.ETHTOOL_FLAG_COMPACT_BITSETS)
+						return nil
+					})
+				},
+
+				Messages: []genetlink.Message{{
+					Data: encode(t, func(ae *netlink.AttributeEncoder) {
+						ae.Nested(_ETHTOOL_A_FEC_HEADER, func(nae *netlink.AttributeEncoder) error {
+							nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, 1)
+							nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, "eth0")
+							return nil
+						})
+						ae.Uint8(_ETHTOOL_A_FEC_AUTO, 1)
+						ae.Uint32(_ETHTOOL_A_FEC_ACTIVE, unix.ETHTOOL_LINK_MODE_FEC_RS_BIT)
+						ae.Nested(_ETHTOOL_A_FEC_STATS, func(nae *netlink.AttributeEncoder) error {
+							tt.stats(nae)
+							return nil
+						})
+					}),
+				}},
+			})
+
+			fec, err := c.FECWithStats(Interface{Index: 1})
+			if err != nil {
+				t.Fatalf("failed to get FEC: %v", err)
+			}
+
+			want := &FEC{
+				Interface: Interface{Index: 1, Name: "eth0"},
+				Active:    unix.ETHTOOL_FEC_RS,
+				Auto:      true,
+				Stats:     tt.want,
+			}
+
+			if diff := cmp.Diff(want, fec); diff != "" {
+				t.Fatalf("unexpected FEC (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLinuxClientModuleEEPROM(t *testing.T) {
+	want := []byte{0x03, 0x04, 0x07}
+
+	c := testClient(t, clientTest{
+		HeaderFlags: netlink.Request,
+		Command:     unix.ETHTOOL_MSG_MODULE_EEPROM_GET,
+		Attributes: func(ae *netlink.AttributeEncoder) {
+			requestIndex(_ETHTOOL_A_MODULE_EEPROM_HEADER, true)(ae)
+			ae.Uint32(_ETHTOOL_A_MODULE_EEPROM_OFFSET, 128)
+			ae.Uint32(_ETHTOOL_A_MODULE_EEPROM_LENGTH, 3)
+			ae.Uint8(_ETHTOOL_A_MODULE_EEPROM_PAGE, 3)
+			ae.Uint8(_ETHTOOL_A_MODULE_EEPROM_BANK, 1)
+			ae.Uint8(_ETHTOOL_A_MODULE_EEPROM_I2C_ADDRESS, I2CAddressA0)
+		},
+
+		Messages: []genetlink.Message{{
+			Data: encode(t, func(ae *netlink.AttributeEncoder) {
+				ae.Nested(_ETHTOOL_A_MODULE_EEPROM_HEADER, func(nae *netlink.AttributeEncoder) error {
+					nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, 1)
+					nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, "eth0")
+					return nil
+				})
+				ae.Bytes(_ETHTOOL_A_MODULE_EEPROM_DATA, want)
+			}),
+		}},
+	})
+
+	b, err := c.ModuleEEPROM(Interface{Index: 1}, ModuleEEPROMRequest{
+		Offset:     128,
+		Length:     3,
+		Page:       3,
+		Bank:       1,
+		I2CAddress: I2CAddressA0,
+	})
+	if err != nil {
+		t.Fatalf("failed to read module EEPROM: %v", err)
+	}
+
+	if diff := cmp.Diff(want, b); diff != "" {
+		t.Fatalf("unexpected module EEPROM data (-want +got):\n%s", diff)
+	}
+}
+
+func TestLinuxClientModuleEEPROMReader(t *testing.T) {
+	skipBigEndian(t)
+
+	// A flat view of the lower page and all 256 upper pages.
+	flat := make([]byte, 128*257)
+	for i := range flat {
+		flat[i] = byte(i ^ i>>8)
+	}
+
+	c := baseClient(t, func(greq genetlink.Message, _ netlink.Message) ([]genetlink.Message, error) {
+		ad, err := netlink.NewAttributeDecoder(greq.Data)
+		if err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+
+		var off, length, page, bank, addr int
+		for ad.Next() {
+			switch ad.Type() {
+			case _ETHTOOL_A_MODULE_EEPROM_OFFSET:
+				off = int(ad.Uint32())
+			case _ETHTOOL_A_MODULE_EEPROM_LENGTH:
+				length = int(ad.Uint32())
+			case _ETHTOOL_A_MODULE_EEPROM_PAGE:
+				page = int(ad.Uint8())
+			case _ETHTOOL_A_MODULE_EEPROM_BANK:
+				bank = int(ad.Uint8())
+			case _ETHTOOL_A_MODULE_EEPROM_I2C_ADDRESS:
+				addr = int(ad.Uint8())
+			}
+		}
+		if err := ad.Err(); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+
+		// Apply the same restrictions as the kernel.
+		if length < 1 || length > 128 || off+length > 256 ||
+			(off < 128 && off+length > 128) || (page != 0 && off < 128) {
+			t.Fatalf("invalid request: offset: %d, length: %d, page: %d", off, length, page)
+		}
+		if bank != 2 || addr != I2CAddressA0 {
+			t.Fatalf("unexpected bank %d or I2C address %d", bank, addr)
+		}
+
+		start := off
+		if page != 0 {
+			start = 128*(page+1) + off - 128
+		}
+
+		return []genetlink.Message{{
+			Data: encode(t, func(ae *netlink.AttributeEncoder) {
+				ae.Bytes(_ETHTOOL_A_MODULE_EEPROM_DATA, flat[start:start+length])
+			}),
+		}}, nil
+	})
+	defer c.Close()
+
+	r := c.ModuleEEPROMReader(Interface{Index: 1}, I2CAddressA0, 2)
+
+	tests := []struct {
+		name   string
+		off, n int
+		err    error
+	}{
+		{name: "lower page", off: 0, n: 128},
+		{name: "page 00h", off: 0, n: 256},
+		{name: "across pages", off: 100, n: 500},
+		{name: "page 03h", off: 512, n: 128},
+		{name: "end", off: len(flat) - 10, n: 20, err: io.EOF},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := make([]byte, tt.n)
+			n, err := r.ReadAt(b, int64(tt.off))
+			if diff := cmp.Diff(tt.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected error (-want +got):\n%s", diff)
+			}
+
+			want := flat[tt.off:min(tt.off+tt.n, len(flat))]
+			if diff := cmp.Diff(want, b[:n]); diff != "" {
+				t.Fatalf("unexpected data (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLinuxClientStats(t *testing.T) {
+	skipBigEndian(t)
+
+	groups := func(names ...string) func(*netlink.AttributeEncoder) {
+		return func(ae *netlink.AttributeEncoder) {
+			bits := make(map[string]bool, len(names))
+			for _, n := range names {
+				bits[n] = true
+			}
+			encodeNamedBitset(ae, _ETHTOOL_A_STATS_GROUPS, bits)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		req   StatsRequest
+		attrs func(ae *netlink.AttributeEncoder)
+		grps  func(ae *netlink.AttributeEncoder)
+		want  *Stats
+	}{
+		{
+			name:  "all groups",
+			attrs: groups("eth-phy", "eth-mac", "eth-ctrl", "rmon"),
+			grps: func(ae *netlink.AttributeEncoder) {
+				encodeStatsGroup(ae, StatsEthPHY, map[uint16]uint64{0: 3}, nil, nil)
+				encodeStatsGroup(ae, StatsEthMAC, map[uint16]uint64{
+					0:  1000,
+					3:  2000,
+					4:  5,
+					6:  64000,
+					12: 128000,
+					21: 1,
+				}, nil, nil)
+				encodeStatsGroup(ae, StatsEthCtrl, map[uint16]uint64{2: 7}, nil, nil)
+				encodeStatsGroup(ae, StatsRMON, map[uint16]uint64{0: 1, 3: 2},
+					[]RMONHistogramBucket{
+						{Low: 64, High: 64, Packets: 10},
+						{Low: 65, High: 127, Packets: 20},
+						{Low: 1519, High: 9216, Packets: 1},
+					},
+					[]RMONHistogramBucket{
+						{Low: 64, High: 64, Packets: 30},
+					},
+				)
+			},
+			want: &Stats{
+				Interface: Interface{Index: 1, Name: "eth0"},
+				EthPHY:    &EthPHYStats{SymbolErrorDuringCarrier: 3},
+				EthMAC: &EthMACStats{
+					FramesTransmittedOK:      1000,
+					FramesReceivedOK:         2000,
+					FrameCheckSequenceErrors: 5,
+					OctetsTransmittedOK:      64000,
+					OctetsReceivedOK:         128000,
+					FrameTooLongErrors:       1,
+				},
+				EthCtrl: &EthCtrlStats{UnsupportedOpcodesReceived: 7},
+				RMON: &RMONStats{
+					UndersizePkts: 1,
+					Jabbers:       2,
+					RXHistogram: []RMONHistogramBucket{
+						{Low: 64, High: 64, Packets: 10},
+						{Low: 65, High: 127, Packets: 20},
+						{Low: 1519, High: 9216, Packets: 1},
+					},
+					TXHistogram: []RMONHistogramBucket{
+						{Low: 64, High: 64, Packets: 30},
+					},
+				},
+			},
+		},
+		{
+			name: "pMAC",
+			req: StatsRequest{
+				Groups: []StatsGroup{StatsEthMAC},
+				Source: StatsSourcePMAC,
+			},
+			attrs: func(ae *netlink.AttributeEncoder) {
+				groups("eth-mac")(ae)
+				ae.Uint32(_ETHTOOL_A_STATS_SRC, uint32(StatsSourcePMAC))
+			},
+			grps: func(ae *netlink.AttributeEncoder) {
+				encodeStatsGroup(ae, StatsEthMAC, map[uint16]uint64{0: 10}, nil, nil)
+			},
+			want: &Stats{
+				Interface: Interface{Index: 1, Name: "eth0"},
+				EthMAC:    &EthMACStats{FramesTransmittedOK: 10},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testClient(t, clientTest{
+				HeaderFlags: netlink.Request,
+				Command:     unix.ETHTOOL_MSG_STATS_GET,
+				Attributes: func(ae *netlink.AttributeEncoder) {
+					requestIndex(_ETHTOOL_A_STATS_HEADER, true)(ae)
+					tt.attrs(ae)
+				},
+
+				Messages: []genetlink.Message{{
+					Data: encode(t, func(ae *netlink.AttributeEncoder) {
+						ae.Nested(_ETHTOOL_A_STATS_HEADER, func(nae *netlink.AttributeEncoder) error {
+							nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, 1)
+							nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, "eth0")
+							return nil
+						})
+						tt.grps(ae)
+					}),
+				}},
+			})
+
+			s, err := c.Stats(Interface{Index: 1}, tt.req)
+			if err != nil {
+				t.Fatalf("failed to get stats: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, s); diff != "" {
+				t.Fatalf("unexpected stats (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLinuxClientPHCVClocks(t *testing.T) {
+	skipBigEndian(t)
+
+	want := []*PHCVClocks{
+		{
+			Interface: Interface{Index: 1, Name: "eth0"},
+			Indices:   []int{2, 3},
+		},
+		{
+			Interface: Interface{Index: 2, Name: "eth1"},
+		},
+	}
+
+	c := testClient(t, clientTest{
+		HeaderFlags: netlink.Request | netlink.Dump,
+		Command:     unix.ETHTOOL_MSG_PHC_VCLOCKS_GET,
+		Attributes:  requestHeader(_ETHTOOL_A_PHC_VCLOCKS_HEADER),
+
+		Messages: []genetlink.Message{
+			encodePHCVClocks(t, *want[0]),
+			encodePHCVClocks(t, *want[1]),
+		},
+	})
+
+	vcs, err := c.AllPHCVClocks()
+	if err != nil {
+		t.Fatalf("failed to get PHC virtual clocks: %v", err)
+	}
+
+	if diff := cmp.Diff(want, vcs); diff != "" {
+		t.Fatalf("unexpected PHC virtual clocks (-want +got):\n%s", diff)
+	}
+}
+
+func TestLinuxClientRSS(t *testing.T) {
+	skipBigEndian(t)
+
+	key := bytes.Repeat([]byte{0x6d, 0x5a}, 20)
+
+	tests := []struct {
+		name    string
+		context int
+		attrs   func(ae *netlink.AttributeEncoder)
+		reply   func(ae *netlink.AttributeEncoder)
+		want    *RSS
+	}{
+		{
+			name:  "default",
+			attrs: requestIndex(_ETHTOOL_A_RSS_HEADER, true),
+			reply: func(ae *netlink.AttributeEncoder) {
+				ae.Uint32(_ETHTOOL_A_RSS_HFUNC, 1<<0)
+				ae.Bytes(_ETHTOOL_A_RSS_INDIR, encodeUint32s(0, 1, 2, 3, 0, 1, 2, 3))
+				ae.Bytes(_ETHTOOL_A_RSS_HKEY, key)
+				ae.Uint32(_ETHTOOL_A_RSS_INPUT_XFRM, 0)
+			},
+			want: &RSS{
+				Interface:        Interface{Index: 1, Name: "eth0"},
+				HashFunction:     "toeplitz",
+				HashKey:          key,
+				IndirectionTable: []int{0, 1, 2, 3, 0, 1, 2, 3},
+			},
+		},
+		{
+			name:    "context",
+			context: 2,
+			attrs: func(ae *netlink.AttributeEncoder) {
+				requestIndex(_ETHTOOL_A_RSS_HEADER, true)(ae)
+				ae.Uint32(_ETHTOOL_A_RSS_CONTEXT, 2)
+			},
+			reply: func(ae *netlink.AttributeEncoder) {
+				ae.Uint32(_ETHTOOL_A_RSS_CONTEXT, 2)
+				ae.Uint32(_ETHTOOL_A_RSS_HFUNC, 1<<1)
+				ae.Bytes(_ETHTOOL_A_RSS_INDIR, encodeUint32s(4, 5))
+				ae.Uint32(_ETHTOOL_A_RSS_INPUT_XFRM, uint32(RSSInputTransformSymmetricXOR))
+			},
+			want: &RSS{
+				Interface:        Interface{Index: 1, Name: "eth0"},
+				Context:          2,
+				HashFunction:     "xor",
+				IndirectionTable: []int{4, 5},
+				InputTransform:   RSSInputTransformSymmetricXOR,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := rssClient(t, unix.ETHTOOL_MSG_RSS_GET, tt.attrs, tt.reply)
+
+			rss, err := c.RSS(Interface{Index: 1}, tt.context)
+			if err != nil {
+				t.Fatalf("failed to get RSS: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, rss); diff != "" {
+				t.Fatalf("unexpected RSS (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLinuxClientSetRSS(t *testing.T) {
+	skipBigEndian(t)
+
+	var (
+		crc32   = "crc32"
+		bogus   = "bogus"
+		xfrm    = RSSInputTransformSymmetricXOR
+		key     = []byte{0x01, 0x02, 0x03, 0x04}
+		noAttrs = func(_ *netlink.AttributeEncoder) {}
+	)
+
+	tests := []struct {
+		name    string
+		context int
+		ru      *RSSUpdate
+		attrs   func(ae *netlink.AttributeEncoder)
+		ok      bool
+	}{
+		{
+			name: "table and key",
+			ru: &RSSUpdate{
+				IndirectionTable: []int{0, 1, 0, 1},
+				HashKey:          key,
+			},
+			attrs: func(ae *netlink.AttributeEncoder) {
+				requestIndex(_ETHTOOL_A_RSS_HEADER, true)(ae)
+				ae.Bytes(_ETHTOOL_A_RSS_INDIR, encodeUint32s(0, 1, 0, 1))
+				ae.Bytes(_ETHTOOL_A_RSS_HKEY, key)
+			},
+			ok: true,
+		},
+		{
+			name:    "context reset",
+			context: 1,
+			ru: &RSSUpdate{
+				HashFunction:     &crc32,
+				IndirectionTable: []int{},
+				InputTransform:   &xfrm,
+			},
+			attrs: func(ae *netlink.AttributeEncoder) {
+				requestIndex(_ETHTOOL_A_RSS_HEADER, true)(ae)
+				ae.Uint32(_ETHTOOL_A_RSS_CONTEXT, 1)
+				ae.Uint32(_ETHTOOL_A_RSS_HFUNC, 1<<2)
+				ae.Bytes(_ETHTOOL_A_RSS_INDIR, nil)
+				ae.Uint32(_ETHTOOL_A_RSS_INPUT_XFRM, uint32(xfrm))
+			},
+			ok: true,
+		},
+		{
+			name:  "unknown hash function",
+			ru:    &RSSUpdate{HashFunction: &bogus},
+			attrs: noAttrs,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := rssClient(t, _ETHTOOL_MSG_RSS_SET, tt.attrs, nil)
+
+			err := c.SetRSS(Interface{Index: 1}, tt.context, tt.ru)
+			if tt.ok && err != nil {
+				t.Fatalf("failed to set RSS: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}
+
+func TestLinuxClientRSSContexts(t *testing.T) {
+	skipBigEndian(t)
+
+	c := baseClient(t, func(greq genetlink.Message, req netlink.Message) ([]genetlink.Message, error) {
+		if diff := cmp.Diff(netlink.Request|netlink.Dump, req.Header.Flags); diff != "" {
+			t.Fatalf("unexpected netlink flags (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(encode(t, requestIndex(_ETHTOOL_A_RSS_HEADER, true)), greq.Data); diff != "" {
+			t.Fatalf("unexpected request attributes (-want +got):\n%s", diff)
+		}
+
+		var msgs []genetlink.Message
+		for ctx, indir := range [][]uint32{{0, 1, 2, 3}, {4, 5, 4, 5}, {6, 7, 6, 7}} {
+			msgs = append(msgs, genetlink.Message{
+				Data: encode(t, func(ae *netlink.AttributeEncoder) {
+					ae.Nested(_ETHTOOL_A_RSS_HEADER, func(nae *netlink.AttributeEncoder) error {
+						nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, 1)
+						nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, "eth0")
+						return nil
+					})
+					// The default context is reported without an ID.
+					if ctx != 0 {
+						ae.Uint32(_ETHTOOL_A_RSS_CONTEXT, uint32(ctx))
+					}
+					ae.Bytes(_ETHTOOL_A_RSS_INDIR, encodeUint32s(indir...))
+				}),
+			})
+		}
+
+		return msgs, nil
+	})
+	defer c.Close()
+
+	rss, err := c.RSSContexts(Interface{Index: 1})
+	if err != nil {
+		t.Fatalf("failed to get RSS contexts: %v", err)
+	}
+
+	var (
+		ctxs   []int
+		queues [][]int
+	)
+	for _, r := range rss {
+		ctxs = append(ctxs, r.Context)
+		queues = append(queues, r.Queues())
+	}
+
+	if diff := cmp.Diff([]int{0, 1, 2}, ctxs); diff != "" {
+		t.Fatalf("unexpected RSS contexts (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([][]int{{0, 1, 2, 3}, {4, 5}, {6, 7}}, queues); diff != "" {
+		t.Fatalf("unexpected RSS queues (-want +got):\n%s", diff)
+	}
+}
+
+func TestLinuxClientCreateRSSContext(t *testing.T) {
+	skipBigEndian(t)
+
+	xor := "xor"
+
+	c := rssClient(t, _ETHTOOL_MSG_RSS_CREATE_ACT,
+		func(ae *netlink.AttributeEncoder) {
+			requestIndex(_ETHTOOL_A_RSS_HEADER, true)(ae)
+			ae.Uint32(_ETHTOOL_A_RSS_HFUNC, 1<<1)
+			ae.Bytes(_ETHTOOL_A_RSS_INDIR, encodeUint32s(8, 9, 10, 11))
+		},
+		func(ae *netlink.AttributeEncoder) {
+			ae.Uint32(_ETHTOOL_A_RSS_CONTEXT, 3)
+			ae.Uint32(_ETHTOOL_A_RSS_HFUNC, 1<<1)
+			ae.Bytes(_ETHTOOL_A_RSS_INDIR, encodeUint32s(8, 9, 10, 11))
+		},
+	)
+
+	ctx, err := c.CreateRSSContext(Interface{Index: 1}, &RSSUpdate{
+		HashFunction:     &xor,
+		IndirectionTable: []int{8, 9, 10, 11},
+	})
+	if err != nil {
+		t.Fatalf("failed to create RSS context: %v", err)
+	}
+
+	if diff := cmp.Diff(3, ctx); diff != "" {
+		t.Fatalf("unexpected RSS context (-want +got):\n%s", diff)
+	}
+}
+
+func TestLinuxClientCreateRSSContextIoctl(t *testing.T) {
+	skipBigEndian(t)
+
+	c := testClient(t, clientTest{
+		Error: genltest.Error(int(unix.EOPNOTSUPP)),
+	})
+
+	// With no configuration the default table must be sent explicitly, as
+	// the kernel rejects requests which change nothing.
+	want := []byte{
+		0x47, 0x00, 0x00, 0x00,
+		0xff, 0xff, 0xff, 0xff,
+		0x04, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+		// Four buckets spread across two queues.
+		0x00, 0x00, 0x00, 0x00,
+		0x01, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+		0x01, 0x00, 0x00, 0x00,
+	}
+
+	c.c.ioctl = func(ifi Interface, b []byte) error {
+		if diff := cmp.Diff(Interface{Index: 1}, ifi); diff != "" {
+			t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+		}
+
+		switch cmd := binary.NativeEndian.Uint32(b[0:4]); cmd {
+		case unix.ETHTOOL_GRSSH:
+			binary.NativeEndian.PutUint32(b[8:12], 4)
+		case unix.ETHTOOL_GRXRINGS:
+			binary.NativeEndian.PutUint64(b[8:16], 2)
+		case unix.ETHTOOL_SRSSH:
+			if diff := cmp.Diff(want, b); diff != "" {
+				t.Fatalf("unexpected ethtool_rxfh (-want +got):\n%s", diff)
+			}
+			binary.NativeEndian.PutUint32(b[4:8], 3)
+		default:
+			t.Fatalf("unexpected ethtool command: %#x", cmd)
+		}
+
+		return nil
+	}
+
+	ctx, err := c.CreateRSSContext(Interface{Index: 1}, nil)
+	if err != nil {
+		t.Fatalf("failed to create RSS context: %v", err)
+	}
+
+	if diff := cmp.Diff(3, ctx); diff != "" {
+		t.Fatalf("unexpected RSS context (-want +got):\n%s", diff)
+	}
+}
+
+func TestLinuxClientDeleteRSSContext(t *testing.T) {
+	c := testClient(t, clientTest{
+		HeaderFlags: netlink.Request | netlink.Acknowledge,
+		Command:     _ETHTOOL_MSG_RSS_DELETE_ACT,
+		Attributes: func(ae *netlink.AttributeEncoder) {
+			requestIndex(_ETHTOOL_A_RSS_HEADER, true)(ae)
+			ae.Uint32(_ETHTOOL_A_RSS_CONTEXT, 3)
+		},
+
+		Messages: []genetlink.Message{{}},
+	})
+
+	if err := c.DeleteRSSContext(Interface{Index: 1}, 3); err != nil {
+		t.Fatalf("failed to delete RSS context: %v", err)
+	}
+}
+
+func TestEncodeRXFH(t *testing.T) {
+	skipBigEndian(t)
+
+	xfrm := RSSInputTransformSymmetricXOR
+
+	tests := []struct {
+		name string
+		b    []byte
+		want []byte
+	}{
+		{
+			name: "create",
+			b: encodeRXFHCreate(&RSSUpdate{
+				IndirectionTable: []int{4, 5},
+				HashKey:          []byte{0x6d, 0x5a},
+			}, 1<<0),
+			want: []byte{
+				// cmd, rss_context, indir_size, key_size
+				0x47, 0x00, 0x00, 0x00,
+				0xff, 0xff, 0xff, 0xff,
+				0x02, 0x00, 0x00, 0x00,
+				0x02, 0x00, 0x00, 0x00,
+				// hfunc, input_xfrm, reserved
+				0x01, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00,
+				// indirection table and key
+				0x04, 0x00, 0x00, 0x00,
+				0x05, 0x00, 0x00, 0x00,
+				0x6d, 0x5a,
+			},
+		},
+		{
+			// An empty table must not be sent as a size of zero, which
+			// would request deletion of the new context.
+			name: "create without table",
+			b:    encodeRXFHCreate(&RSSUpdate{IndirectionTable: []int{}}, 1<<1),
+			want: []byte{
+				0x47, 0x00, 0x00, 0x00,
+				0xff, 0xff, 0xff, 0xff,
+				0xff, 0xff, 0xff, 0xff,
+				0x00, 0x00, 0x00, 0x00,
+				0x02, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			name: "create with input transform",
+			b: encodeRXFHCreate(&RSSUpdate{
+				InputTransform: &xfrm,
+			}, 1<<0),
+			want: []byte{
+				0x47, 0x00, 0x00, 0x00,
+				0xff, 0xff, 0xff, 0xff,
+				0xff, 0xff, 0xff, 0xff,
+				0x00, 0x00, 0x00, 0x00,
+				0x01, 0x01, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			name: "delete",
+			b:    encodeRXFHDelete(3),
+			want: []byte{
+				0x47, 0x00, 0x00, 0x00,
+				0x03, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.b); diff != "" {
+				t.Fatalf("unexpected ethtool_rxfh (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLinuxClientPLCAConfig(t *testing.T) {
+	want := &PLCAConfig{
+		Interface:          Interface{Index: 1, Name: "eth0"},
+		Version:            0x0a10,
+		Enabled:            true,
+		NodeID:             0,
+		NodeCount:          8,
+		TOTimerBitTimes:    32,
+		BurstCount:         0,
+		BurstTimerBitTimes: 128,
+	}
+
+	c := testClient(t, clientTest{
+		HeaderFlags: netlink.Request,
+		Command:     unix.ETHTOOL_MSG_PLCA_GET_CFG,
+		Attributes:  requestIndex(_ETHTOOL_A_PLCA_HEADER, true),
+
+		Messages: []genetlink.Message{encodePLCAConfig(t, *want)},
+	})
+
+	pc, err := c.PLCAConfig(Interface{Index: 1})
+	if err != nil {
+		t.Fatalf("failed to get PLCA config: %v", err)
+	}
+
+	if diff := cmp.Diff(want, pc); diff != "" {
+		t.Fatalf("unexpected PLCA config (-want +got):\n%s", diff)
+	}
+}
+
+func TestLinuxClientSetPLCAConfig(t *testing.T) {
+	var (
+		on    = true
+		id    = 3
+		burst = 2
+	)
+
+	tests := []struct {
+		name       string
+		pu         *PLCAConfigUpdate
+		attrs      func(ae *netlink.AttributeEncoder)
+		nlErr, err error
+	}{
+		{
+			name:  "EPERM",
+			pu:    &PLCAConfigUpdate{},
+			attrs: requestIndex(_ETHTOOL_A_PLCA_HEADER, true),
+			nlErr: genltest.Error(int(unix.EPERM)),
+			err:   os.ErrPermission,
+		},
+		{
+			name: "follower",
+			pu: &PLCAConfigUpdate{
+				Enabled:    &on,
+				NodeID:     &id,
+				BurstCount: &burst,
+			},
+			attrs: func(ae *netlink.AttributeEncoder) {
+				requestIndex(_ETHTOOL_A_PLCA_HEADER, true)(ae)
+				ae.Uint8(_ETHTOOL_A_PLCA_ENABLED, 1)
+				ae.Uint32(_ETHTOOL_A_PLCA_NODE_ID, 3)
+				ae.Uint32(_ETHTOOL_A_PLCA_BURST_CNT, 2)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testClient(t, clientTest{
+				HeaderFlags: netlink.Request | netlink.Acknowledge,
+				Command:     unix.ETHTOOL_MSG_PLCA_SET_CFG,
+				Attributes:  tt.attrs,
+
+				Messages: []genetlink.Message{{}},
+				Error:    tt.nlErr,
+			})
+
+			err := c.SetPLCAConfig(Interface{Index: 1}, tt.pu)
+			if diff := cmp.Diff(tt.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected error (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLinuxClientPLCAStatus(t *testing.T) {
+	c := testClient(t, clientTest{
+		HeaderFlags: netlink.Request,
+		Command:     unix.ETHTOOL_MSG_PLCA_GET_STATUS,
+		Attributes:  requestIndex(_ETHTOOL_A_PLCA_HEADER, true),
+
+		Messages: []genetlink.Message{{
+			Data: encode(t, func(ae *netlink.AttributeEncoder) {
+				ae.Nested(_ETHTOOL_A_PLCA_HEADER, func(nae *netlink.AttributeEncoder) error {
+					nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, 1)
+					nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, "eth0")
+					return nil
+				})
+				ae.Uint8(_ETHTOOL_A_PLCA_STATUS, 1)
+			}),
+		}},
+	})
+
+	ps, err := c.PLCAStatus(Interface{Index: 1})
+	if err != nil {
+		t.Fatalf("failed to get PLCA status: %v", err)
+	}
+
+	want := &PLCAStatus{
+		Interface: Interface{Index: 1, Name: "eth0"},
+		Active:    true,
+	}
+
+	if diff := cmp.Diff(want, ps); diff != "" {
+		t.Fatalf("unexpected PLCA status (-want +got):\n%s", diff)
+	}
+}
+
+func requestHeader(typ uint16) func(*netlink.AttributeEncoder) {
+	return func(ae *netlink.AttributeEncoder) {
+		ae.Nested(typ, func(nae *netlink.AttributeEncoder) error {
+			headerFlags(nae)
+			return nil
+		})
+	}
+}
+
+func requestIndex(typ uint16, compactBitsets bool) func(*netlink.AttributeEncoder) {
+	return func(ae *netlink.AttributeEncoder) {
+		ae.Nested(typ, func(nae *netlink.AttributeEncoder) error {
+			nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, 1)
+			if compactBitsets {
+				headerFlags(nae)
+			}
+			return nil
+		})
+	}
+}
+
+func requestName(typ uint16) func(*netlink.AttributeEncoder) {
+	return func(ae *netlink.AttributeEncoder) {
+		ae.Nested(typ, func(nae *netlink.AttributeEncoder) error {
+			nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, "eth1")
+			headerFlags(nae)
+			return nil
+		})
+	}
+}
+
+func requestBoth(typ uint16, compactBitsets bool) func(*netlink.AttributeEncoder) {
+	return func(ae *netlink.AttributeEncoder) {
+		ae.Nested(typ, func(nae *netlink.AttributeEncoder) error {
+			nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, 2)
+			nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, "eth1")
+			if compactBitsets {
+				headerFlags(nae)
+			}
+			return nil
+		})
+	}
+}
+
+func headerFlags(ae *netlink.AttributeEncoder) {
+	ae.Uint32(unix.ETHTOOL_A_HEADER_FLAGS, unix.ETHTOOL_FLAG_COMPACT_BITSETS)
+}
+
+func encodeLinkInfo(t *testing.T, li LinkInfo) genetlink.Message {
+	t.Helper()
+
+	return genetlink.Message{
+		Data: encode(t, func(ae *netlink.AttributeEncoder) {
+			ae.Nested(unix.ETHTOOL_A_LINKINFO_HEADER, func(nae *netlink.AttributeEncoder) error {
+				nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(li.Interface.Index))
+				nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, li.Interface.Name)
+				return nil
+			})
+
+			ae.Uint8(unix.ETHTOOL_A_LINKINFO_PORT, uint8(li.Port))
+		}),
+	}
+}
+
+func encodeLinkMode(t *testing.T, lm LinkMode) genetlink.Message {
+	t.Helper()
+
+	return genetlink.Message{
+		Data: encode(t, func(ae *netlink.AttributeEncoder) {
+			ae.Nested(unix.ETHTOOL_A_LINKMODES_HEADER, func(nae *netlink.AttributeEncoder) error {
+				nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(lm.Interface.Index))
+				nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, lm.Interface.Name)
+				return nil
+			})
+
+			ae.Uint32(unix.ETHTOOL_A_LINKMODES_SPEED, uint32(lm.SpeedMegabits))
+
+			packALMs := func(typ uint16, alms []AdvertisedLinkMode) {
+				ae.Nested(typ, func(nae *netlink.AttributeEncoder) error {
+					fn := packALMBitset(alms)
+					nae.Uint32(unix.ETHTOOL_A_BITSET_SIZE, uint32(len(linkModes)))
+					nae.Do(unix.ETHTOOL_A_BITSET_VALUE, fn)
+					nae.Do(unix.ETHTOOL_A_BITSET_MASK, fn)
+					return nil
+				})
+			}
+
+			packALMs(unix.ETHTOOL_A_LINKMODES_OURS, lm.Ours)
+			packALMs(unix.ETHTOOL_A_LINKMODES_PEER, lm.Peer)
+
+			ae.Uint8(unix.ETHTOOL_A_LINKMODES_DUPLEX, uint8(lm.Duplex))
+			ae.Uint8(unix.ETHTOOL_A_LINKMODES_AUTONEG, uint8(lm.Autoneg))
+		}),
+	}
+}
+
+func encodeLinkState(t *testing.T, ls LinkState) genetlink.Message {
+	t.Helper()
+
+	return genetlink.Message{
+		Data: encode(t, func(ae *netlink.AttributeEncoder) {
+			ae.Nested(unix.ETHTOOL_A_LINKSTATE_HEADER, func(nae *netlink.AttributeEncoder) error {
+				nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(ls.Interface.Index))
+				nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, ls.Interface.Name)
+				return nil
+			})
+
+			// uint8 boolean conversion.
+			var link uint8
+			if ls.Link {
+				link = 1
+			}
+
+			ae.Uint8(unix.ETHTOOL_A_LINKSTATE_LINK, link)
+		}),
+	}
+}
+
+func encodeWOL(t *testing.T, wol WakeOnLAN) genetlink.Message {
+	t.Helper()
+
+	return genetlink.Message{
+		Data: encode(t, func(ae *netlink.AttributeEncoder) {
+			ae.Nested(unix.ETHTOOL_A_WOL_HEADER, func(nae *netlink.AttributeEncoder) error {
+				nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(wol.Interface.Index))
+				nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, wol.Interface.Name)
+				return nil
+			})
+
+			wol.encode(ae)
+		}),
+	}
+}
+
+func encodeFeatures(t *testing.T, f Features) genetlink.Message {
+	t.Helper()
+
+	return genetlink.Message{
+		Data: encode(t, func(ae *netlink.AttributeEncoder) {
+			ae.Nested(unix.ETHTOOL_A_FEATURES_HEADER, func(nae *netlink.AttributeEncoder) error {
+				nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(f.Interface.Index))
+				nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, f.Interface.Name)
+				return nil
+			})
+
+			encodeBitList(ae, unix.ETHTOOL_A_FEATURES_HW, f.Hardware)
+			encodeBitList(ae, unix.ETHTOOL_A_FEATURES_WANTED, f.Wanted)
+			encodeBitList(ae, unix.ETHTOOL_A_FEATURES_ACTIVE, f.Active)
+			encodeBitList(ae, unix.ETHTOOL_A_FEATURES_NOCHANGE, f.NoChange)
+		}),
+	}
+}
+
+// encodeBitList packs the names of the set bits into a verbose list bitset, as
+// the kernel does for features and timestamping capabilities.
+func encodeBitList(ae *netlink.AttributeEncoder, typ uint16, names map[string]bool) {
+	ae.Nested(typ, func(nae *netlink.AttributeEncoder) error {
+		nae.Flag(unix.ETHTOOL_A_BITSET_NOMASK, true)
+		nae.Nested(unix.ETHTOOL_A_BITSET_BITS, func(nnae *netlink.AttributeEncoder) error {
+			for name := range names {
+				nnae.Nested(unix.ETHTOOL_A_BITSET_BITS_BIT, func(nnnae *netlink.AttributeEncoder) error {
+					nnnae.String(unix.ETHTOOL_A_BITSET_BIT_NAME, name)
+					return nil
+				})
+			}
+			return nil
+		})
+		return nil
+	})
+}
+
+func encodeTimestampInfo(t *testing.T, ti TimestampInfo) genetlink.Message {
+	t.Helper()
+
+	return genetlink.Message{
+		Data: encode(t, func(ae *netlink.AttributeEncoder) {
+			ae.Nested(unix.ETHTOOL_A_TSINFO_HEADER, func(nae *netlink.AttributeEncoder) error {
+				nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(ti.Interface.Index))
+				nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, ti.Interface.Name)
+				return nil
+			})
+
+			encodeBitList(ae, unix.ETHTOOL_A_TSINFO_TIMESTAMPING, ti.Timestamping)
+			encodeBitList(ae, unix.ETHTOOL_A_TSINFO_TX_TYPES, ti.TXTypes)
+			encodeBitList(ae, unix.ETHTOOL_A_TSINFO_RX_FILTERS, ti.RXFilters)
+			if ti.PHCIndex >= 0 {
+				ae.Uint32(unix.ETHTOOL_A_TSINFO_PHC_INDEX, uint32(ti.PHCIndex))
+			}
+
+			if ti.Stats != nil {
+				ae.Nested(unix.ETHTOOL_A_TSINFO_STATS, func(nae *netlink.AttributeEncoder) error {
+					// Mirror the kernel, which only uses 64 bits for values
+					// which do not fit in 32 bits.
+					put := func(typ uint16, v uint64) {
+						if v > math.MaxUint32 {
+							nae.Uint64(typ, v)
+						} else {
+							nae.Uint32(typ, uint32(v))
+						}
+					}
+
+					put(_ETHTOOL_A_TS_STAT_TX_PKTS, ti.Stats.TXPackets)
+					put(_ETHTOOL_A_TS_STAT_TX_LOST, ti.Stats.TXLost)
+					put(_ETHTOOL_A_TS_STAT_TX_ERR, ti.Stats.TXErrors)
+					return nil
+				})
+			}
+		}),
+	}
+}
+
+func encodeStringSets(t *testing.T, sets []*StringSet) genetlink.Message {
+	t.Helper()
+
+	return genetlink.Message{
+		Data: encode(t, func(ae *netlink.AttributeEncoder) {
+			ae.Nested(unix.ETHTOOL_A_STRSET_STRINGSETS, func(nae *netlink.AttributeEncoder) error {
+				for _, set := range sets {
+					nae.Nested(unix.ETHTOOL_A_STRINGSETS_STRINGSET, func(nnae *netlink.AttributeEncoder) error {
+						nnae.Uint32(unix.ETHTOOL_A_STRINGSET_ID, uint32(set.ID))
+						nnae.Uint32(unix.ETHTOOL_A_STRINGSET_COUNT, uint32(len(set.Strings)))
+						nnae.Nested(unix.ETHTOOL_A_STRINGSET_STRINGS, func(sae *netlink.AttributeEncoder) error {
+							for i, str := range set.Strings {
+								sae.Nested(unix.ETHTOOL_A_STRINGS_STRING, func(ssae *netlink.AttributeEncoder) error {
+									ssae.Uint32(unix.ETHTOOL_A_STRING_INDEX, uint32(i))
+									ssae.String(unix.ETHTOOL_A_STRING_VALUE, str)
+									return nil
+								})
+							}
+							return nil
+						})
+						return nil
+					})
+				}
+				return nil
+			})
+		}),
+	}
+}
+
+func encodeRings(t *testing.T, r Rings) genetlink.Message {
+	t.Helper()
+
+	return genetlink.Message{
+		Data: encode(t, func(ae *netlink.AttributeEncoder) {
+			ae.Nested(unix.ETHTOOL_A_RINGS_HEADER, func(nae *netlink.AttributeEncoder) error {
+				nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(r.Interface.Index))
+				nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, r.Interface.Name)
+				return nil
+			})
+
+			ae.Uint32(unix.ETHTOOL_A_RINGS_RX_MAX, uint32(r.RXMax))
+			ae.Uint32(unix.ETHTOOL_A_RINGS_RX_MINI_MAX, uint32(r.RXMiniMax))
+			ae.Uint32(unix.ETHTOOL_A_RINGS_RX_JUMBO_MAX, uint32(r.RXJumboMax))
+			ae.Uint32(unix.ETHTOOL_A_RINGS_TX_MAX, uint32(r.TXMax))
+			ae.Uint32(unix.ETHTOOL_A_RINGS_RX, uint32(r.RX))
+			ae.Uint32(unix.ETHTOOL_A_RINGS_RX_MINI, uint32(r.RXMini))
+			ae.Uint32(unix.ETHTOOL_A_RINGS_RX_JUMBO, uint32(r.RXJumbo))
+			ae.Uint32(unix.ETHTOOL_A_RINGS_TX, uint32(r.TX))
+			ae.Uint32(unix.ETHTOOL_A_RINGS_RX_BUF_LEN, uint32(r.RXBufLen))
+			ae.Uint8(unix.ETHTOOL_A_RINGS_TCP_DATA_SPLIT, uint8(r.TCPDataSplit))
+			ae.Uint32(unix.ETHTOOL_A_RINGS_CQE_SIZE, uint32(r.CQESize))
+			encodeBool(ae, unix.ETHTOOL_A_RINGS_TX_PUSH, &r.TXPush)
+			encodeBool(ae, unix.ETHTOOL_A_RINGS_RX_PUSH, &r.RXPush)
+			ae.Uint32(unix.ETHTOOL_A_RINGS_TX_PUSH_BUF_LEN, uint32(r.TXPushBufLen))
+			ae.Uint32(unix.ETHTOOL_A_RINGS_TX_PUSH_BUF_LEN_MAX, uint32(r.TXPushBufLenMax))
+		}),
+	}
+}
+
+func encodeChannels(t *testing.T, ch Channels) genetlink.Message {
+	t.Helper()
+
+	return genetlink.Message{
+		Data: encode(t, func(ae *netlink.AttributeEncoder) {
+			ae.Nested(unix.ETHTOOL_A_CHANNELS_HEADER, func(nae *netlink.AttributeEncoder) error {
+				nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(ch.Interface.Index))
+				nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, ch.Interface.Name)
+				return nil
+			})
+
+			ae.Uint32(unix.ETHTOOL_A_CHANNELS_RX_MAX, uint32(ch.RXMax))
+			ae.Uint32(unix.ETHTOOL_A_CHANNELS_TX_MAX, uint32(ch.TXMax))
+			ae.Uint32(unix.ETHTOOL_A_CHANNELS_OTHER_MAX, uint32(ch.OtherMax))
+			ae.Uint32(unix.ETHTOOL_A_CHANNELS_COMBINED_MAX, uint32(ch.CombinedMax))
+			ae.Uint32(unix.ETHTOOL_A_CHANNELS_RX_COUNT, uint32(ch.RX))
+			ae.Uint32(unix.ETHTOOL_A_CHANNELS_TX_COUNT, uint32(ch.TX))
+			ae.Uint32(unix.ETHTOOL_A_CHANNELS_OTHER_COUNT, uint32(ch.Other))
+			ae.Uint32(unix.ETHTOOL_A_CHANNELS_COMBINED_COUNT, uint32(ch.Combined))
+		}),
+	}
+}
+
+func encodeCoalesce(t *testing.T, c Coalesce) genetlink.Message {
+	t.Helper()
+
+	return genetlink.Message{
+		Data: encode(t, func(ae *netlink.AttributeEncoder) {
+			ae.Nested(unix.ETHTOOL_A_COALESCE_HEADER, func(nae *netlink.AttributeEncoder) error {
+				nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(c.Interface.Index))
+				nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, c.Interface.Name)
+				return nil
+			})
+
+			ae.Uint32(unix.ETHTOOL_A_COALESCE_RX_USECS, uint32(c.RXUsecs))
+			ae.Uint32(unix.ETHTOOL_A_COALESCE_RX_MAX_FRAMES, uint32(c.RXMaxFrames))
+			ae.Uint32(unix.ETHTOOL_A_COALESCE_RX_USECS_IRQ, uint32(c.RXUsecsIRQ))
+			ae.Uint32(unix.ETHTOOL_A_COALESCE_RX_MAX_FRAMES_IRQ, uint32(c.RXMaxFramesIRQ))
+			ae.Uint32(unix.ETHTOOL_A_COALESCE_TX_USECS, uint32(c.TXUsecs))
+			ae.Uint32(unix.ETHTOOL_A_COALESCE_TX_MAX_FRAMES, uint32(c.TXMaxFrames))
+			ae.Uint32(unix.ETHTOOL_A_COALESCE_TX_USECS_IRQ, uint32(c.TXUsecsIRQ))
+			ae.Uint32(unix.ETHTOOL_A_COALESCE_TX_MAX_FRAMES_IRQ, uint32(c.TXMaxFramesIRQ))
+			ae.Uint32(unix.ETHTOOL_A_COALESCE_STATS_BLOCK_USECS, uint32(c.StatsBlockUsecs))
+			encodeBool(ae, unix.ETHTOOL_A_COALESCE_USE_ADAPTIVE_RX, &c.AdaptiveRX)
+			encodeBool(ae, unix.ETHTOOL_A_COALESCE_USE_ADAPTIVE_TX, &c.AdaptiveTX)
+			ae.Uint32(unix.ETHTOOL_A_COALESCE_PKT_RATE_LOW, uint32(c.PacketRateLow))
+			ae.Uint32(unix.ETHTOOL_A_COALESCE_RX_USECS_LOW, uint32(c.RXUsecsLow))
+			ae.Uint32(unix.ETHTOOL_A_COALESCE_RX_MAX_FRAMES_LOW, uint32(c.RXMaxFramesLow))
+			ae.Uint32(unix.ETHTOOL_A_COALESCE_TX_USECS_LOW, uint32(c.TXUsecsLow))
+			ae.Uint32(unix.ETHTOOL_A_COALESCE_TX_MAX_FRAMES_LOW, uint32(c.TXMaxFramesLow))
+			ae.Uint32(unix.ETHTOOL_A_COALESCE_PKT_RATE_HIGH, uint32(c.PacketRateHigh))
+			ae.Uint32(unix.ETHTOOL_A_COALESCE_RX_USECS_HIGH, uint32(c.RXUsecsHigh))
+			ae.Uint32(unix.ETHTOOL_A_COALESCE_RX_MAX_FRAMES_HIGH, uint32(c.RXMaxFramesHigh))
+			ae.Uint32(unix.ETHTOOL_A_COALESCE_TX_USECS_HIGH, uint32(c.TXUsecsHigh))
+			ae.Uint32(unix.ETHTOOL_A_COALESCE_TX_MAX_FRAMES_HIGH, uint32(c.TXMaxFramesHigh))
+			ae.Uint32(unix.ETHTOOL_A_COALESCE_RATE_SAMPLE_INTERVAL, uint32(c.RateSampleIntervalSeconds))
+			encodeBool(ae, unix.ETHTOOL_A_COALESCE_USE_CQE_MODE_TX, &c.CQEModeTX)
+			encodeBool(ae, unix.ETHTOOL_A_COALESCE_USE_CQE_MODE_RX, &c.CQEModeRX)
+			ae.Uint32(_ETHTOOL_A_COALESCE_TX_AGGR_MAX_BYTES, uint32(c.TXAggrMaxBytes))
+			ae.Uint32(_ETHTOOL_A_COALESCE_TX_AGGR_MAX_FRAMES, uint32(c.TXAggrMaxFrames))
+			ae.Uint32(_ETHTOOL_A_COALESCE_TX_AGGR_TIME_USECS, uint32(c.TXAggrTimeUsecs))
+		}),
+	}
+}
+
+func encodePause(t *testing.T, p Pause) genetlink.Message {
+	t.Helper()
+
+	return genetlink.Message{
+		Data: encode(t, func(ae *netlink.AttributeEncoder) {
+			ae.Nested(unix.ETHTOOL_A_PAUSE_HEADER, func(nae *netlink.AttributeEncoder) error {
+				nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(p.Interface.Index))
+				nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, p.Interface.Name)
+				return nil
+			})
+
+			encodeBool(ae, unix.ETHTOOL_A_PAUSE_AUTONEG, &p.Autoneg)
+			encodeBool(ae, unix.ETHTOOL_A_PAUSE_RX, &p.RX)
+			encodeBool(ae, unix.ETHTOOL_A_PAUSE_TX, &p.TX)
+
+			if p.Stats != nil {
+				ae.Nested(unix.ETHTOOL_A_PAUSE_STATS, func(nae *netlink.AttributeEncoder) error {
+					nae.Uint64(unix.ETHTOOL_A_PAUSE_STAT_TX_FRAMES, p.Stats.TXFrames)
+					nae.Uint64(unix.ETHTOOL_A_PAUSE_STAT_RX_FRAMES, p.Stats.RXFrames)
+					return nil
+				})
+			}
+		}),
+	}
+}
+
+func encodeEEE(t *testing.T, e EEE) genetlink.Message {
+	t.Helper()
+
+	return genetlink.Message{
+		Data: encode(t, func(ae *netlink.AttributeEncoder) {
+			ae.Nested(unix.ETHTOOL_A_EEE_HEADER, func(nae *netlink.AttributeEncoder) error {
+				nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(e.Interface.Index))
+				nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, e.Interface.Name)
+				return nil
+			})
+
+			packALMs := func(typ uint16, alms []AdvertisedLinkMode) {
+				ae.Nested(typ, func(nae *netlink.AttributeEncoder) error {
+					fn := packALMBitset(alms)
+					nae.Uint32(unix.ETHTOOL_A_BITSET_SIZE, uint32(len(linkModes)))
+					nae.Do(unix.ETHTOOL_A_BITSET_VALUE, fn)
+					nae.Do(unix.ETHTOOL_A_BITSET_MASK, fn)
+					return nil
+				})
+			}
+
+			packALMs(unix.ETHTOOL_A_EEE_MODES_OURS, e.Ours)
+			packALMs(unix.ETHTOOL_A_EEE_MODES_PEER, e.Peer)
+
+			encodeBool(ae, unix.ETHTOOL_A_EEE_ACTIVE, &e.Active)
+			encodeBool(ae, unix.ETHTOOL_A_EEE_ENABLED, &e.Enabled)
+			encodeBool(ae, unix.ETHTOOL_A_EEE_TX_LPI_ENABLED, &e.TXLPIEnabled)
+			ae.Uint32(unix.ETHTOOL_A_EEE_TX_LPI_TIMER, uint32(e.TXLPITimerUsecs))
+		}),
+	}
+}
+
+func encodeCableTest(t *testing.T, status uint8, ctr *CableTestResult) genetlink.Message {
+	t.Helper()
+
+	return genetlink.Message{
+		Header: genetlink.Header{Command: unix.ETHTOOL_MSG_CABLE_TEST_NTF},
+		Data: encode(t, func(ae *netlink.AttributeEncoder) {
+			ae.Nested(unix.ETHTOOL_A_CABLE_TEST_NTF_HEADER, func(nae *netlink.AttributeEncoder) error {
+				nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(ctr.Interface.Index))
+				nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, ctr.Interface.Name)
+				return nil
+			})
+
+			ae.Uint8(unix.ETHTOOL_A_CABLE_TEST_NTF_STATUS, status)
+
+			if len(ctr.Pairs) == 0 {
+				return
+			}
+
+			ae.Nested(unix.ETHTOOL_A_CABLE_TEST_NTF_NEST, func(nae *netlink.AttributeEncoder) error {
+				for _, p := range ctr.Pairs {
+					nae.Nested(unix.ETHTOOL_A_CABLE_NEST_RESULT, func(nnae *netlink.AttributeEncoder) error {
+						nnae.Uint8(unix.ETHTOOL_A_CABLE_RESULT_PAIR, uint8(p.Pair))
+						nnae.Uint8(unix.ETHTOOL_A_CABLE_RESULT_CODE, uint8(p.Code))
+						return nil
+					})
+				}
+				for _, p := range ctr.Pairs {
+					if p.FaultLengthCentimeters < 0 {
+						continue
+					}
+
+					nae.Nested(unix.ETHTOOL_A_CABLE_NEST_FAULT_LENGTH, func(nnae *netlink.AttributeEncoder) error {
+						nnae.Uint8(unix.ETHTOOL_A_CABLE_FAULT_LENGTH_PAIR, uint8(p.Pair))
+						nnae.Uint32(unix.ETHTOOL_A_CABLE_FAULT_LENGTH_CM, uint32(p.FaultLengthCentimeters))
+						return nil
+					})
+				}
+				return nil
+			})
+		}),
+	}
+}
+
+func encodeCableTestTDR(t *testing.T, r *CableTestTDRResult) genetlink.Message {
+	t.Helper()
+
+	return genetlink.Message{
+		Header: genetlink.Header{Command: unix.ETHTOOL_MSG_CABLE_TEST_TDR_NTF},
+		Data: encode(t, func(ae *netlink.AttributeEncoder) {
+			ae.Nested(unix.ETHTOOL_A_CABLE_TEST_TDR_NTF_HEADER, func(nae *netlink.AttributeEncoder) error {
+				nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(r.Interface.Index))
+				nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, r.Interface.Name)
+				return nil
+			})
+
+			ae.Uint8(unix.ETHTOOL_A_CABLE_TEST_TDR_NTF_STATUS, unix.ETHTOOL_A_CABLE_TEST_NTF_STATUS_COMPLETED)
+
+			// Like the kernel's PHY drivers, report the pulse and step before
+			// interleaving the amplitudes of each pair at each distance.
+			ae.Nested(unix.ETHTOOL_A_CABLE_TEST_TDR_NTF_NEST, func(nae *netlink.AttributeEncoder) error {
+				for _, mv := range r.PulsesMillivolts {
+					nae.Nested(unix.ETHTOOL_A_CABLE_TDR_NEST_PULSE, func(nnae *netlink.AttributeEncoder) error {
+						nnae.Int16(unix.ETHTOOL_A_CABLE_PULSE_mV, int16(mv))
+						return nil
+					})
+				}
+
+				for _, s := range r.Steps {
+					nae.Nested(unix.ETHTOOL_A_CABLE_TDR_NEST_STEP, func(nnae *netlink.AttributeEncoder) error {
+						nnae.Uint32(unix.ETHTOOL_A_CABLE_STEP_FIRST_DISTANCE, uint32(s.FirstCentimeters))
+						nnae.Uint32(unix.ETHTOOL_A_CABLE_STEP_LAST_DISTANCE, uint32(s.LastCentimeters))
+						nnae.Uint32(unix.ETHTOOL_A_CABLE_STEP_STEP_DISTANCE, uint32(s.StepCentimeters))
+						return nil
+					})
+				}
+
+				for i := range r.Pairs[0].Samples {
+					for _, p := range r.Pairs {
+						nae.Nested(unix.ETHTOOL_A_CABLE_TDR_NEST_AMPLITUDE, func(nnae *netlink.AttributeEncoder) error {
+							nnae.Uint8(unix.ETHTOOL_A_CABLE_AMPLITUDE_PAIR, uint8(p.Pair))
+							nnae.Int16(unix.ETHTOOL_A_CABLE_AMPLITUDE_mV, int16(p.Samples[i].AmplitudeMillivolts))
+							return nil
+						})
+					}
+				}
+				return nil
+			})
+		}),
+	}
+}
+
+func encodeTunnelInfo(t *testing.T, ti TunnelInfo) genetlink.Message {
+	t.Helper()
+
+	return genetlink.Message{
+		Data: encode(t, func(ae *netlink.AttributeEncoder) {
+			ae.Nested(unix.ETHTOOL_A_TUNNEL_INFO_HEADER, func(nae *netlink.AttributeEncoder) error {
+				nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(ti.Interface.Index))
+				nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, ti.Interface.Name)
+				return nil
+			})
+
+			ae.Nested(unix.ETHTOOL_A_TUNNEL_INFO_UDP_PORTS, func(nae *netlink.AttributeEncoder) error {
+				for _, table := range ti.UDPTables {
+					nae.Nested(unix.ETHTOOL_A_TUNNEL_UDP_TABLE, func(nnae *netlink.AttributeEncoder) error {
+						nnae.Uint32(unix.ETHTOOL_A_TUNNEL_UDP_TABLE_SIZE, uint32(table.Size))
+
+						var types uint32
+						for _, typ := range table.Types {
+							types |= 1 << typ
+						}
+
+						nnae.Nested(unix.ETHTOOL_A_TUNNEL_UDP_TABLE_TYPES, func(bae *netlink.AttributeEncoder) error {
+							bae.Flag(unix.ETHTOOL_A_BITSET_NOMASK, true)
+							bae.Uint32(unix.ETHTOOL_A_BITSET_SIZE, 3)
+							bae.Uint32(unix.ETHTOOL_A_BITSET_VALUE, types)
+							return nil
+						})
+
+						for _, p := range table.Ports {
+							nnae.Nested(unix.ETHTOOL_A_TUNNEL_UDP_TABLE_ENTRY, func(eae *netlink.AttributeEncoder) error {
+								eae.Bytes(unix.ETHTOOL_A_TUNNEL_UDP_ENTRY_PORT,
+									binary.BigEndian.AppendUint16(nil, uint16(p.Port)))
+								eae.Uint32(unix.ETHTOOL_A_TUNNEL_UDP_ENTRY_TYPE, uint32(p.Type))
+								return nil
+							})
+						}
+						return nil
+					})
+				}
+				return nil
+			})
+		}),
+	}
+}
+
+func encodePHCVClocks(t *testing.T, vc PHCVClocks) genetlink.Message {
+	t.Helper()
+
+	return genetlink.Message{
+		Data: encode(t, func(ae *netlink.AttributeEncoder) {
+			ae.Nested(_ETHTOOL_A_PHC_VCLOCKS_HEADER, func(nae *netlink.AttributeEncoder) error {
+				nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(vc.Interface.Index))
+				nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, vc.Interface.Name)
+				return nil
+			})
+
+			if len(vc.Indices) == 0 {
+				return
+			}
+
+			var b []byte
+			for _, i := range vc.Indices {
+				b = binary.NativeEndian.AppendUint32(b, uint32(i))
+			}
+
+			ae.Uint32(_ETHTOOL_A_PHC_VCLOCKS_NUM, uint32(len(vc.Indices)))
+			ae.Bytes(_ETHTOOL_A_PHC_VCLOCKS_INDEX, b)
+		}),
+	}
+}
+
+func encodeModule(t *testing.T, m Module) genetlink.Message {
+	t.Helper()
+
+	return genetlink.Message{
+		Data: encode(t, func(ae *netlink.AttributeEncoder) {
+			ae.Nested(_ETHTOOL_A_MODULE_HEADER, func(nae *netlink.AttributeEncoder) error {
+				nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(m.Interface.Index))
+				nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, m.Interface.Name)
+				return nil
+			})
+			ae.Uint8(_ETHTOOL_A_MODULE_POWER_MODE_POLICY, uint8(m.PowerModePolicy))
+			ae.Uint8(_ETHTOOL_A_MODULE_POWER_MODE, uint8(m.PowerMode))
+		}),
+	}
+}
+
+// rssClient returns a Client which serves the RSS hash functions string set
+// and verifies that RSS requests use cmd with the expected attributes.
+func rssClient(t *testing.T, cmd uint8, attrs, reply func(ae *netlink.AttributeEncoder)) *Client {
+	t.Helper()
+
+	c := baseClient(t, func(greq genetlink.Message, _ netlink.Message) ([]genetlink.Message, error) {
+		switch greq.Header.Command {
+		case unix.ETHTOOL_MSG_STRSET_GET:
+			return []genetlink.Message{encodeStringSets(t, []*StringSet{{
+				ID:      StringSetRSSHashFuncs,
+				Strings: []string{"toeplitz", "xor", "crc32"},
+			}})}, nil
+		case cmd:
+			if diff := cmp.Diff(encode(t, attrs), greq.Data); diff != "" {
+				t.Fatalf("unexpected request attributes (-want +got):\n%s", diff)
+			}
+
+			if reply == nil {
+				return []genetlink.Message{{}}, nil
+			}
+
+			return []genetlink.Message{{
+				Data: encode(t, func(ae *netlink.AttributeEncoder) {
+					ae.Nested(_ETHTOOL_A_RSS_HEADER, func(nae *netlink.AttributeEncoder) error {
+						nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, 1)
+						nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, "eth0")
+						return nil
+					})
+					reply(ae)
+				}),
+			}}, nil
+		default:
+			t.Fatalf("unexpected command: %d", greq.Header.Command)
+			return nil, nil
+		}
+	})
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+
+	return c
+}
+
+func encodeUint32s(vs ...uint32) []byte {
+	var b []byte
+	for _, v := range vs {
+		b = binary.NativeEndian.AppendUint32(b, v)
+	}
+	return b
+}
+
+func encodePLCAConfig(t *testing.T, pc PLCAConfig) genetlink.Message {
+	t.Helper()
+
+	return genetlink.Message{
+		Data: encode(t, func(ae *netlink.AttributeEncoder) {
+			ae.Nested(_ETHTOOL_A_PLCA_HEADER, func(nae *netlink.AttributeEncoder) error {
+				nae.Uint32(unix.ETHTOOL_A_HEADER_DEV_INDEX, uint32(pc.Interface.Index))
+				nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, pc.Interface.Name)
+				return nil
+			})
+			ae.Uint16(_ETHTOOL_A_PLCA_VERSION, pc.Version)
+			encodeBool(ae, _ETHTOOL_A_PLCA_ENABLED, &pc.Enabled)
+			ae.Uint32(_ETHTOOL_A_PLCA_NODE_CNT, uint32(pc.NodeCount))
+			ae.Uint32(_ETHTOOL_A_PLCA_NODE_ID, uint32(pc.NodeID))
+			ae.Uint32(_ETHTOOL_A_PLCA_TO_TMR, uint32(pc.TOTimerBitTimes))
+			ae.Uint32(_ETHTOOL_A_PLCA_BURST_CNT, uint32(pc.BurstCount))
+			ae.Uint32(_ETHTOOL_A_PLCA_BURST_TMR, uint32(pc.BurstTimerBitTimes))
+		}),
+	}
+}
+
+func encodeStatsGroup(ae *netlink.AttributeEncoder, g StatsGroup, stats map[uint16]uint64, rx, tx []RMONHistogramBucket) {
+	ae.Nested(_ETHTOOL_A_STATS_GRP, func(nae *netlink.AttributeEncoder) error {
+		nae.Uint32(_ETHTOOL_A_STATS_GRP_ID, uint32(g))
+		nae.Uint32(_ETHTOOL_A_STATS_GRP_SS_ID, 0)
+
+		for i, v := range stats {
+			nae.Nested(_ETHTOOL_A_STATS_GRP_STAT, func(snae *netlink.AttributeEncoder) error {
+				snae.Uint64(i, v)
+				return nil
+			})
+		}
+
+		for typ, bs := range map[uint16][]RMONHistogramBucket{
+			_ETHTOOL_A_STATS_GRP_HIST_RX: rx,
+			_ETHTOOL_A_STATS_GRP_HIST_TX: tx,
+		} {
+			for _, b := range bs {
+				nae.Nested(typ, func(bnae *netlink.AttributeEncoder) error {
+					bnae.Uint32(_ETHTOOL_A_STATS_GRP_HIST_BKT_LOW, uint32(b.Low))
+					bnae.Uint32(_ETHTOOL_A_STATS_GRP_HIST_BKT_HI, uint32(b.High))
+					bnae.Uint64(_ETHTOOL_A_STATS_GRP_HIST_VAL, b.Packets)
+					return nil
+				})
+			}
+		}
+		return nil
+	})
 }
 
 func packALMBitset(alms []AdvertisedLinkMode) func() ([]byte, error) {
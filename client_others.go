@@ -4,7 +4,9 @@
 package ethtool
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"runtime"
 )
 
@@ -32,8 +34,89 @@ func (c *client) SetFEC(_ FEC) error                                  { return e
 func (c *client) AllPrivateFlags() ([]*PrivateFlags, error)           { return nil, errUnsupported }
 func (c *client) PrivateFlags(_ Interface) (*PrivateFlags, error)     { return nil, errUnsupported }
 func (c *client) SetPrivateFlags(_ PrivateFlags) error                { return errUnsupported }
+func (c *client) AllFeatures() ([]*Features, error)                   { return nil, errUnsupported }
+func (c *client) Features(_ Interface) (*Features, error)             { return nil, errUnsupported }
+func (c *client) AllRings() ([]*Rings, error)                         { return nil, errUnsupported }
+func (c *client) Rings(_ Interface) (*Rings, error)                   { return nil, errUnsupported }
+func (c *client) SetRings(_ Interface, _ *RingsUpdate) error          { return errUnsupported }
+func (c *client) AllChannels() ([]*Channels, error)                   { return nil, errUnsupported }
+func (c *client) Channels(_ Interface) (*Channels, error)             { return nil, errUnsupported }
+func (c *client) SetChannels(_ Interface, _ *ChannelsUpdate) error    { return errUnsupported }
+func (c *client) AllCoalesce() ([]*Coalesce, error)                   { return nil, errUnsupported }
+func (c *client) Coalesce(_ Interface) (*Coalesce, error)             { return nil, errUnsupported }
+func (c *client) SetCoalesce(_ Interface, _ *CoalesceUpdate) error    { return errUnsupported }
+func (c *client) AllPause() ([]*Pause, error)                         { return nil, errUnsupported }
+func (c *client) Pause(_ Interface) (*Pause, error)                   { return nil, errUnsupported }
+func (c *client) PauseWithStats(_ Interface) (*Pause, error)          { return nil, errUnsupported }
+func (c *client) SetPause(_ Interface, _ *PauseUpdate) error          { return errUnsupported }
+func (c *client) AllEEE() ([]*EEE, error)                             { return nil, errUnsupported }
+func (c *client) EEE(_ Interface) (*EEE, error)                       { return nil, errUnsupported }
+func (c *client) SetEEE(_ Interface, _ *EEEUpdate) error              { return errUnsupported }
+func (c *client) AllTimestampInfo() ([]*TimestampInfo, error)         { return nil, errUnsupported }
+func (c *client) TimestampInfo(_ Interface) (*TimestampInfo, error)   { return nil, errUnsupported }
+func (c *client) AllTunnelInfo() ([]*TunnelInfo, error)               { return nil, errUnsupported }
+func (c *client) TunnelInfo(_ Interface) (*TunnelInfo, error)         { return nil, errUnsupported }
+func (c *client) FECWithStats(_ Interface) (*FEC, error)              { return nil, errUnsupported }
+func (c *client) AllStats(_ StatsRequest) ([]*Stats, error)           { return nil, errUnsupported }
+func (c *client) Stats(_ Interface, _ StatsRequest) (*Stats, error)   { return nil, errUnsupported }
+func (c *client) AllPHCVClocks() ([]*PHCVClocks, error)               { return nil, errUnsupported }
+func (c *client) PHCVClocks(_ Interface) (*PHCVClocks, error)         { return nil, errUnsupported }
+func (c *client) AllModules() ([]*Module, error)                      { return nil, errUnsupported }
+func (c *client) Module(_ Interface) (*Module, error)                 { return nil, errUnsupported }
+func (c *client) SetModule(_ Interface, _ *ModuleUpdate) error        { return errUnsupported }
+func (c *client) AllPSE() ([]*PSE, error)                             { return nil, errUnsupported }
+func (c *client) PSE(_ Interface) (*PSE, error)                       { return nil, errUnsupported }
+func (c *client) SetPSE(_ Interface, _ *PSEUpdate) error              { return errUnsupported }
+func (c *client) RSS(_ Interface, _ int) (*RSS, error)                { return nil, errUnsupported }
+func (c *client) SetRSS(_ Interface, _ int, _ *RSSUpdate) error       { return errUnsupported }
+func (c *client) PLCAConfig(_ Interface) (*PLCAConfig, error)         { return nil, errUnsupported }
+func (c *client) PLCAStatus(_ Interface) (*PLCAStatus, error)         { return nil, errUnsupported }
 func (c *client) Close() error                                        { return errUnsupported }
 
+func (c *client) ModuleEEPROM(_ Interface, _ ModuleEEPROMRequest) ([]byte, error) {
+	return nil, errUnsupported
+}
+
+func (c *client) SetFeatures(_ Interface, _ map[string]bool) (map[string]bool, error) {
+	return nil, errUnsupported
+}
+
+func (c *client) StringSets(_ Interface, _ ...StringSetID) ([]*StringSet, error) {
+	return nil, errUnsupported
+}
+
+func (c *client) Monitor(_ context.Context) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) { yield(nil, errUnsupported) }
+}
+
+func (c *client) CableTest(_ context.Context, _ Interface) (*CableTestResult, error) {
+	return nil, errUnsupported
+}
+
+func (c *client) CableTestTDR(_ context.Context, _ Interface, _ *CableTestTDRConfig) (*CableTestTDRResult, error) {
+	return nil, errUnsupported
+}
+
+func (c *client) TimestampInfoWithStats(_ Interface) (*TimestampInfo, error) {
+	return nil, errUnsupported
+}
+
+func (c *client) RSSContexts(_ Interface) ([]*RSS, error) {
+	return nil, errUnsupported
+}
+
+func (c *client) CreateRSSContext(_ Interface, _ *RSSUpdate) (int, error) {
+	return 0, errUnsupported
+}
+
+func (c *client) DeleteRSSContext(_ Interface, _ int) error {
+	return errUnsupported
+}
+
+func (c *client) SetPLCAConfig(_ Interface, _ *PLCAConfigUpdate) error {
+	return errUnsupported
+}
+
 func (f *FEC) Supported() FECModes { return 0 }
 
 func (f FECMode) String() string  { return "unsupported" }
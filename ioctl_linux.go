@@ -0,0 +1,186 @@
+//go:build linux
+// +build linux
+
+package ethtool
+
+import (
+	"encoding/binary"
+	"net"
+	"os"
+	"runtime"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// Constants for the legacy ethtool ioctl interface, used where the netlink
+// interface lacks support on older kernels.
+//
+// TODO: get these into x/sys/unix
+const (
+	_ETH_RXFH_CONTEXT_ALLOC   = 0xffffffff //nolint:revive
+	_ETH_RXFH_INDIR_NO_CHANGE = 0xffffffff //nolint:revive
+)
+
+// rxfhHeaderLen is the length of struct ethtool_rxfh, excluding the trailing
+// indirection table and hash key.
+const rxfhHeaderLen = 24
+
+// rxnfcLen is large enough to hold struct ethtool_rxnfc, excluding the
+// trailing rule locations, on all architectures.
+const rxnfcLen = 192
+
+// rxfhCreate creates an RSS context using the ETHTOOL_SRSSH ioctl and returns
+// its ID.
+func (c *client) rxfhCreate(ifi Interface, ru *RSSUpdate, hfunc uint32) (int, error) {
+	if len(ru.IndirectionTable) == 0 && len(ru.HashKey) == 0 && hfunc == 0 {
+		// The kernel rejects requests which change nothing, so explicitly
+		// request the default indirection table instead.
+		indir, err := c.rxfhDefaultTable(ifi)
+		if err != nil {
+			return 0, err
+		}
+
+		def := *ru
+		def.IndirectionTable = indir
+		ru = &def
+	}
+
+	b := encodeRXFHCreate(ru, hfunc)
+	if err := c.ioctl(ifi, b); err != nil {
+		return 0, err
+	}
+
+	// The kernel writes the ID of the new context back into the request.
+	return int(binary.NativeEndian.Uint32(b[4:8])), nil
+}
+
+// rxfhDefaultTable builds the default RSS indirection table for ifi, which
+// spreads the hash buckets across the RX queues round-robin.
+func (c *client) rxfhDefaultTable(ifi Interface) ([]int, error) {
+	// Requesting no table or key only reports their sizes.
+	b := make([]byte, rxfhHeaderLen)
+	binary.NativeEndian.PutUint32(b[0:4], unix.ETHTOOL_GRSSH)
+	if err := c.ioctl(ifi, b); err != nil {
+		return nil, err
+	}
+	size := binary.NativeEndian.Uint32(b[8:12])
+
+	r := make([]byte, rxnfcLen)
+	binary.NativeEndian.PutUint32(r[0:4], unix.ETHTOOL_GRXRINGS)
+	if err := c.ioctl(ifi, r); err != nil {
+		return nil, err
+	}
+	rings := binary.NativeEndian.Uint64(r[8:16])
+
+	if size == 0 || rings == 0 {
+		// The device has no indirection table to configure.
+		return nil, os.NewSyscallError("ioctl", unix.EOPNOTSUPP)
+	}
+
+	indir := make([]int, size)
+	for i := range indir {
+		indir[i] = i % int(rings)
+	}
+
+	return indir, nil
+}
+
+// encodeRXFHCreate packs the ETHTOOL_SRSSH request which creates an RSS
+// context configured with ru.
+func encodeRXFHCreate(ru *RSSUpdate, hfunc uint32) []byte {
+	// Before Linux 6.8 the input transform was a reserved byte which must be
+	// zero, so only set it when requested.
+	var xfrm uint8
+	if ru.InputTransform != nil {
+		xfrm = uint8(*ru.InputTransform)
+	}
+
+	// An empty indirection table would request deletion of the new context,
+	// so leave the device default in place instead.
+	indir := ru.IndirectionTable
+	if len(indir) == 0 {
+		indir = nil
+	}
+
+	return encodeRXFH(_ETH_RXFH_CONTEXT_ALLOC, indir, ru.HashKey, uint8(hfunc), xfrm)
+}
+
+// rxfhDelete deletes an RSS context using the ETHTOOL_SRSSH ioctl.
+func (c *client) rxfhDelete(ifi Interface, context int) error {
+	return c.ioctl(ifi, encodeRXFHDelete(context))
+}
+
+// encodeRXFHDelete packs the ETHTOOL_SRSSH request which deletes an RSS
+// context.
+func encodeRXFHDelete(context int) []byte {
+	// An empty indirection table deletes any context other than the default.
+	return encodeRXFH(uint32(context), []int{}, nil, 0, 0)
+}
+
+// encodeRXFH packs a struct ethtool_rxfh for the ETHTOOL_SRSSH ioctl. A nil
+// indirection table leaves the table unchanged. A non-nil but empty table
+// resets the table of the default context, or deletes any other context. An
+// empty key leaves the key unchanged.
+func encodeRXFH(context uint32, indir []int, key []byte, hfunc, xfrm uint8) []byte {
+	indirSize := uint32(_ETH_RXFH_INDIR_NO_CHANGE)
+	if indir != nil {
+		indirSize = uint32(len(indir))
+	}
+
+	b := make([]byte, rxfhHeaderLen, rxfhHeaderLen+4*len(indir)+len(key))
+	binary.NativeEndian.PutUint32(b[0:4], unix.ETHTOOL_SRSSH)
+	binary.NativeEndian.PutUint32(b[4:8], context)
+	binary.NativeEndian.PutUint32(b[8:12], indirSize)
+	binary.NativeEndian.PutUint32(b[12:16], uint32(len(key)))
+	b[16] = hfunc
+	b[17] = xfrm
+
+	for _, q := range indir {
+		b = binary.NativeEndian.AppendUint32(b, uint32(q))
+	}
+
+	return append(b, key...)
+}
+
+// An ifreqData is a struct ifreq whose union contains a pointer to the data
+// for an ethtool ioctl.
+type ifreqData struct {
+	name [unix.IFNAMSIZ]byte
+	data unsafe.Pointer
+	_    [24 - unsafe.Sizeof(uintptr(0))]byte
+}
+
+// ethtoolIoctl issues a SIOCETHTOOL ioctl for ifi with the command encoded in
+// b, which the kernel may modify in place.
+func ethtoolIoctl(ifi Interface, b []byte) error {
+	// The ioctl interface identifies devices by name.
+	name := ifi.Name
+	if name == "" {
+		nifi, err := net.InterfaceByIndex(ifi.Index)
+		if err != nil {
+			return err
+		}
+		name = nifi.Name
+	}
+	if len(name) >= unix.IFNAMSIZ {
+		return os.NewSyscallError("ioctl", unix.EINVAL)
+	}
+
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return os.NewSyscallError("socket", err)
+	}
+	defer unix.Close(fd)
+
+	ifr := ifreqData{data: unsafe.Pointer(&b[0])}
+	copy(ifr.name[:], name)
+
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), unix.SIOCETHTOOL, uintptr(unsafe.Pointer(&ifr)))
+	runtime.KeepAlive(b)
+	if errno != 0 {
+		return os.NewSyscallError("ioctl", errno)
+	}
+
+	return nil
+}
@@ -0,0 +1,517 @@
+package transceiver
+
+import (
+	"fmt"
+	"io"
+)
+
+// A CMIS contains the identification and diagnostic data of a Common
+// Management Interface Specification (CMIS) 4.x or 5.x module, such as a
+// QSFP-DD or OSFP module.
+type CMIS struct {
+	Identifier    Identifier
+	RevisionMajor int
+	RevisionMinor int
+
+	// FlatMemory reports whether the module only implements the lower page
+	// and upper page 00h.
+	FlatMemory bool
+
+	ModuleState    ModuleState
+	ActiveFirmware FirmwareVersion
+	MediaType      MediaType
+	Connector      Connector
+	Vendor         Vendor
+
+	// Applications contains the applications advertised by the module, in
+	// order of their application select codes, starting at 1.
+	Applications []Application
+
+	// Module level monitored values and flags.
+	TemperatureCelsius float64
+	VoltageVolts       float64
+	Flags              CMISModuleFlags
+
+	// Lanes contains the per-lane state and monitored values from each bank
+	// of upper page 11h. Each bank contributes up to 8 lanes, limited to the
+	// largest host or media lane count of the advertised applications. Lanes
+	// is empty for flat memory modules.
+	Lanes []CMISLane
+
+	// Thresholds contains the alarm and warning thresholds from upper page
+	// 02h. Thresholds is nil for flat memory modules or if upper page 02h
+	// was not provided.
+	Thresholds *CMISThresholds
+
+	// BaseChecksumValid reports the validity of the upper page 00h checksum.
+	BaseChecksumValid bool
+}
+
+// A ModuleState is the state of a CMIS module.
+type ModuleState int
+
+// Possible ModuleState values.
+const (
+	ModuleStateLowPower  ModuleState = 1
+	ModuleStatePowerUp   ModuleState = 2
+	ModuleStateReady     ModuleState = 3
+	ModuleStatePowerDown ModuleState = 4
+	ModuleStateFault     ModuleState = 5
+)
+
+// String returns the string representation of a ModuleState.
+func (s ModuleState) String() string {
+	switch s {
+	case ModuleStateLowPower:
+		return "LowPower"
+	case ModuleStatePowerUp:
+		return "PowerUp"
+	case ModuleStateReady:
+		return "Ready"
+	case ModuleStatePowerDown:
+		return "PowerDown"
+	case ModuleStateFault:
+		return "Fault"
+	default:
+		return "Invalid"
+	}
+}
+
+// A DataPathState is the state of the data path of a CMIS lane.
+type DataPathState int
+
+// Possible DataPathState values.
+const (
+	DataPathStateDeactivated DataPathState = 1
+	DataPathStateInit        DataPathState = 2
+	DataPathStateDeinit      DataPathState = 3
+	DataPathStateActivated   DataPathState = 4
+	DataPathStateTXTurnOn    DataPathState = 5
+	DataPathStateTXTurnOff   DataPathState = 6
+	DataPathStateInitialized DataPathState = 7
+)
+
+// String returns the string representation of a DataPathState.
+func (s DataPathState) String() string {
+	switch s {
+	case DataPathStateDeactivated:
+		return "Deactivated"
+	case DataPathStateInit:
+		return "Init"
+	case DataPathStateDeinit:
+		return "Deinit"
+	case DataPathStateActivated:
+		return "Activated"
+	case DataPathStateTXTurnOn:
+		return "TXTurnOn"
+	case DataPathStateTXTurnOff:
+		return "TXTurnOff"
+	case DataPathStateInitialized:
+		return "Initialized"
+	default:
+		return "Invalid"
+	}
+}
+
+// A MediaType is the media type of a CMIS module, which determines the SFF-8024
+// table used to interpret media interface IDs.
+type MediaType uint8
+
+// Possible MediaType values.
+const (
+	MediaTypeUndefined     MediaType = 0x00
+	MediaTypeMMF           MediaType = 0x01
+	MediaTypeSMF           MediaType = 0x02
+	MediaTypePassiveCopper MediaType = 0x03
+	MediaTypeActiveCable   MediaType = 0x04
+	MediaTypeBASET         MediaType = 0x05
+)
+
+// String returns the string representation of a MediaType.
+func (m MediaType) String() string {
+	switch m {
+	case MediaTypeUndefined:
+		return "Undefined"
+	case MediaTypeMMF:
+		return "MMF"
+	case MediaTypeSMF:
+		return "SMF"
+	case MediaTypePassiveCopper:
+		return "PassiveCopper"
+	case MediaTypeActiveCable:
+		return "ActiveCable"
+	case MediaTypeBASET:
+		return "BASE-T"
+	default:
+		return "Invalid"
+	}
+}
+
+// A FirmwareVersion is the major and minor version of module firmware.
+type FirmwareVersion struct {
+	Major, Minor int
+}
+
+// String returns the string representation of a FirmwareVersion.
+func (v FirmwareVersion) String() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// An Application is a combination of host and media interfaces advertised by
+// a CMIS module. Interface IDs are defined by SFF-8024, and media interface
+// IDs are interpreted according to the module's MediaType.
+type Application struct {
+	HostInterfaceID  uint8
+	MediaInterfaceID uint8
+	HostLaneCount    int
+	MediaLaneCount   int
+
+	// HostLaneAssignment is a bitmask of the host lanes on which an
+	// instance of the application may begin, where bit 0 is lane 1.
+	HostLaneAssignment uint8
+}
+
+// CMISModuleFlags contains the latched module level flags of a CMIS module.
+type CMISModuleFlags struct {
+	StateChanged bool
+	Temperature  AlarmFlags
+	Voltage      AlarmFlags
+}
+
+// A CMISLane contains the data path state, monitored values, and flags of a
+// single CMIS lane.
+type CMISLane struct {
+	DataPathState DataPathState
+
+	TXPowerMilliwatts float64
+	TXBiasMilliamps   float64
+	RXPowerMilliwatts float64
+
+	TXPowerFlags AlarmFlags
+	TXBiasFlags  AlarmFlags
+	RXPowerFlags AlarmFlags
+
+	// Latched fault, loss of signal, and CDR loss of lock indicators.
+	TXFault  bool
+	TXLOS    bool
+	TXCDRLOL bool
+	RXLOS    bool
+	RXCDRLOL bool
+}
+
+// CMISThresholds contains the alarm and warning thresholds of a CMIS module.
+type CMISThresholds struct {
+	TemperatureCelsius Thresholds
+	VoltageVolts       Thresholds
+	TXPowerMilliwatts  Thresholds
+	TXBiasMilliamps    Thresholds
+	RXPowerMilliwatts  Thresholds
+}
+
+// Offsets and lengths of the regions of CMIS module memory used by the
+// decoder.
+const (
+	// cmisLen is the length of the lower page and upper page 00h.
+	cmisLen = 2 * pageLen
+	// cmisPage02Len is the length of module memory through upper page 02h.
+	cmisPage02Len = 4 * pageLen
+	// cmisPage11Off and cmisPage11Len are the offset of upper page 11h and
+	// the length of module memory through upper page 11h.
+	cmisPage11Off = 0x12 * pageLen
+	cmisPage11Len = cmisPage11Off + pageLen
+)
+
+// cmisLanesPerBank is the number of lanes described by each bank.
+const cmisLanesPerBank = 8
+
+// ReadCMIS reads and parses the EEPROM of a CMIS module. bank is called to
+// obtain an io.ReaderAt for each bank of module memory the module supports,
+// beginning with bank 0. For example, using an *ethtool.Client:
+//
+//	cmis, err := transceiver.ReadCMIS(func(bank int) io.ReaderAt {
+//		return c.ModuleEEPROMReader(ifi, ethtool.I2CAddressA0, bank)
+//	})
+func ReadCMIS(bank func(n int) io.ReaderAt) (*CMIS, error) {
+	r := bank(0)
+
+	b := make([]byte, cmisLen, cmisPage11Len)
+	if err := readAll(r, b, 0); err != nil {
+		return nil, fmt.Errorf("transceiver: failed to read CMIS EEPROM: %w", err)
+	}
+	if cmisFlatMemory(b) {
+		return ParseCMIS(b)
+	}
+
+	// Upper pages 01h and 02h hold advertisements and thresholds, and upper
+	// page 11h holds the per-lane state of each bank.
+	b = b[:cmisPage11Len]
+	if err := readAll(r, b[cmisLen:cmisPage02Len], cmisLen); err != nil {
+		return nil, fmt.Errorf("transceiver: failed to read CMIS upper pages 01h-02h: %w", err)
+	}
+
+	n := cmisBanks(b)
+	banks := make([][]byte, n)
+	banks[0] = b
+	for i := range banks {
+		if i > 0 {
+			r = bank(i)
+			banks[i] = make([]byte, cmisPage11Len)
+		}
+
+		if err := readAll(r, banks[i][cmisPage11Off:], cmisPage11Off); err != nil {
+			return nil, fmt.Errorf("transceiver: failed to read CMIS bank %d upper page 11h: %w", i, err)
+		}
+	}
+
+	return ParseCMIS(banks[0], banks[1:]...)
+}
+
+// ParseCMIS parses the EEPROM of a CMIS module. b must contain at least the
+// lower page and upper page 00h of bank 0, laid out as a flat address space.
+// If b also contains upper pages 01h and 02h, additional applications and
+// thresholds are parsed, and if b extends through upper page 11h, the lanes of
+// bank 0 are parsed.
+//
+// banks may contain the module memory of banks 1 and later, in the same
+// layout as b, for modules with more than 8 lanes. Only upper page 11h of each
+// additional bank is used, and b must then extend through upper page 11h.
+func ParseCMIS(b []byte, banks ...[]byte) (*CMIS, error) {
+	if err := checkLen(b, cmisLen, "CMIS EEPROM"); err != nil {
+		return nil, err
+	}
+
+	id := Identifier(b[0])
+	switch id {
+	case IdentifierQSFPDD, IdentifierOSFP, IdentifierSFPDD, IdentifierDSFP, IdentifierQSFPCMIS:
+	default:
+		return nil, fmt.Errorf("transceiver: identifier %s is not a CMIS module", id)
+	}
+
+	c := &CMIS{
+		Identifier:    id,
+		RevisionMajor: int(b[1] >> 4),
+		RevisionMinor: int(b[1] & 0x0f),
+		FlatMemory:    cmisFlatMemory(b),
+		ModuleState:   ModuleState(b[3] >> 1 & 0x07),
+		ActiveFirmware: FirmwareVersion{
+			Major: int(b[39]),
+			Minor: int(b[40]),
+		},
+		MediaType: MediaType(b[85]),
+		Connector: Connector(b[203]),
+		Vendor: Vendor{
+			Name:         str(b[129:145]),
+			PartNumber:   str(b[148:164]),
+			Revision:     str(b[164:166]),
+			SerialNumber: str(b[166:182]),
+		},
+		TemperatureCelsius: temperature(b[14:16]),
+		VoltageVolts:       voltage(b[16:18]),
+		Flags: CMISModuleFlags{
+			StateChanged: b[8]&(1<<0) != 0,
+			Temperature:  cmisAlarmFlags(b[9]),
+			Voltage:      cmisAlarmFlags(b[9] >> 4),
+		},
+		BaseChecksumValid: checksum(b[128:222], b[222]),
+	}
+
+	copy(c.Vendor.OUI[:], b[145:148])
+	c.Vendor.DateCode, c.Vendor.LotCode = dateCode(b[182:190])
+
+	// Applications 1 through 8 are advertised in the lower page, and 9
+	// through 15 in upper page 01h. The list ends early if an unused
+	// application is found.
+	descs := b[86:118]
+	paged := !c.FlatMemory && len(b) >= cmisPage02Len
+	if paged {
+		descs = append(descs[:len(descs):len(descs)], b[pageLen+223:pageLen+251]...)
+	}
+	for i := 0; i+4 <= len(descs); i += 4 {
+		d := descs[i : i+4]
+		if d[0] == 0xff {
+			break
+		}
+
+		c.Applications = append(c.Applications, Application{
+			HostInterfaceID:    d[0],
+			MediaInterfaceID:   d[1],
+			HostLaneCount:      int(d[2] >> 4),
+			MediaLaneCount:     int(d[2] & 0x0f),
+			HostLaneAssignment: d[3],
+		})
+	}
+
+	if !paged {
+		return c, nil
+	}
+
+	// Upper pages 01h and 02h, indexed by offsets within the 256 bytes
+	// formed by the lower page and the selected upper page.
+	var (
+		p01 = b[1*pageLen : 3*pageLen]
+		p02 = b[2*pageLen : 4*pageLen]
+	)
+
+	// The TX bias monitors are scaled by a multiplier advertised in upper
+	// page 01h.
+	mult := 1 << (p01[160] >> 3 & 0x03)
+	txBias := func(b []byte) float64 {
+		return bias(b) * float64(mult)
+	}
+
+	c.Thresholds = &CMISThresholds{
+		TemperatureCelsius: thresholds(p02[128:], temperature),
+		VoltageVolts:       thresholds(p02[136:], voltage),
+		TXPowerMilliwatts:  thresholds(p02[176:], power),
+		TXBiasMilliamps:    thresholds(p02[184:], txBias),
+		RXPowerMilliwatts:  thresholds(p02[192:], power),
+	}
+
+	n := cmisLaneCount(c.Applications)
+	for i, bank := range append([][]byte{b}, banks...) {
+		if len(bank) < cmisPage11Len {
+			if i == 0 && len(banks) == 0 {
+				// Bank 0 lanes are optional when no other banks are present.
+				break
+			}
+
+			return nil, fmt.Errorf("transceiver: CMIS bank %d must be at least %d bytes, got %d",
+				i, cmisPage11Len, len(bank))
+		}
+
+		c.Lanes = append(c.Lanes, cmisLanes(bank[cmisPage11Off-pageLen:cmisPage11Len], n, txBias)...)
+	}
+
+	return c, nil
+}
+
+// DOM returns the monitoring values of the CMIS module, evaluated against its
+// thresholds if available. Only the media lanes of each bank are included.
+func (c *CMIS) DOM() *DOM {
+	var temp, vcc, bias, tx, rx *Thresholds
+	if t := c.Thresholds; t != nil {
+		temp, vcc = &t.TemperatureCelsius, &t.VoltageVolts
+		bias, tx, rx = &t.TXBiasMilliamps, &t.TXPowerMilliwatts, &t.RXPowerMilliwatts
+	}
+
+	d := &DOM{
+		TemperatureCelsius: newReading(c.TemperatureCelsius, temp),
+		VoltageVolts:       newReading(c.VoltageVolts, vcc),
+	}
+
+	// The optical monitors only apply to media lanes, and any further lanes
+	// of a bank would read as zero and evaluate as alarms.
+	n := cmisLaneCount(c.Applications)
+	m := cmisMediaLaneCount(c.Applications, n)
+	for i, l := range c.Lanes {
+		if i%n >= m {
+			continue
+		}
+
+		d.Lanes = append(d.Lanes, newDOMLane(
+			l.TXBiasMilliamps, l.TXPowerMilliwatts, l.RXPowerMilliwatts,
+			bias, tx, rx,
+		))
+	}
+
+	return d
+}
+
+// cmisLanes parses the first n lanes of upper page 11h, indexed by offsets
+// within the 256 bytes formed by the lower page and the selected upper page.
+func cmisLanes(p []byte, n int, txBias func([]byte) float64) []CMISLane {
+	lanes := make([]CMISLane, n)
+	for i := range lanes {
+		// Each lane's data path state is stored in ascending nibbles, while
+		// the flag bytes hold 1 bit per lane.
+		bit := func(off int) bool {
+			return p[off]&(1<<i) != 0
+		}
+		flags := func(off int) AlarmFlags {
+			return AlarmFlags{
+				HighAlarm:   bit(off),
+				LowAlarm:    bit(off + 1),
+				HighWarning: bit(off + 2),
+				LowWarning:  bit(off + 3),
+			}
+		}
+
+		lanes[i] = CMISLane{
+			DataPathState:     DataPathState(p[128+i/2] >> (4 * (i % 2)) & 0x0f),
+			TXPowerMilliwatts: power(p[154+2*i:]),
+			TXBiasMilliamps:   txBias(p[170+2*i:]),
+			RXPowerMilliwatts: power(p[186+2*i:]),
+			TXPowerFlags:      flags(139),
+			TXBiasFlags:       flags(143),
+			RXPowerFlags:      flags(149),
+			TXFault:           bit(135),
+			TXLOS:             bit(136),
+			TXCDRLOL:          bit(137),
+			RXLOS:             bit(147),
+			RXCDRLOL:          bit(148),
+		}
+	}
+
+	return lanes
+}
+
+// cmisLaneCount returns the number of lanes in each bank of a CMIS module,
+// which is the largest host or media lane count of its applications. All lanes
+// of a bank are assumed to be present if no applications are advertised.
+func cmisLaneCount(apps []Application) int {
+	var n int
+	for _, a := range apps {
+		n = max(n, a.HostLaneCount, a.MediaLaneCount)
+	}
+	if n == 0 || n > cmisLanesPerBank {
+		return cmisLanesPerBank
+	}
+
+	return n
+}
+
+// cmisMediaLaneCount returns the number of media lanes in each bank of a CMIS
+// module with n lanes per bank, which is the largest media lane count of its
+// applications, or n if no applications advertise media lanes.
+func cmisMediaLaneCount(apps []Application, n int) int {
+	var m int
+	for _, a := range apps {
+		m = max(m, a.MediaLaneCount)
+	}
+	if m == 0 || m > n {
+		return n
+	}
+
+	return m
+}
+
+// cmisFlatMemory reports whether a CMIS module only implements the lower page
+// and upper page 00h.
+func cmisFlatMemory(b []byte) bool {
+	return b[2]&(1<<7) != 0
+}
+
+// cmisBanks returns the number of banks supported by a paged CMIS module, as
+// advertised in upper page 01h.
+func cmisBanks(b []byte) int {
+	switch b[pageLen+142] & 0x03 {
+	case 0x01:
+		return 2
+	case 0x02:
+		return 4
+	default:
+		return 1
+	}
+}
+
+// cmisAlarmFlags decodes AlarmFlags from the low 4 bits of b, which are
+// ordered high alarm, low alarm, high warning, and low warning from the least
+// significant bit.
+func cmisAlarmFlags(b byte) AlarmFlags {
+	return AlarmFlags{
+		HighAlarm:   b&(1<<0) != 0,
+		LowAlarm:    b&(1<<1) != 0,
+		HighWarning: b&(1<<2) != 0,
+		LowWarning:  b&(1<<3) != 0,
+	}
+}
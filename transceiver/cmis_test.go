@@ -0,0 +1,336 @@
+package transceiver
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseCMIS(t *testing.T) {
+	// The same module with flat memory, which has no upper pages.
+	flat := cmisDR4(0)[:cmisLen]
+	flat[2] |= 1 << 7
+
+	tests := []struct {
+		name  string
+		b     []byte
+		banks [][]byte
+		c     *CMIS
+		ok    bool
+	}{
+		{
+			name: "short",
+			b:    make([]byte, cmisLen-1),
+		},
+		{
+			name: "not CMIS",
+			b: func() []byte {
+				b := make([]byte, cmisLen)
+				b[0] = byte(IdentifierQSFP28)
+				return b
+			}(),
+		},
+		{
+			name:  "short bank",
+			b:     cmisDR4(0),
+			banks: [][]byte{make([]byte, cmisPage02Len)},
+		},
+		{
+			// Lanes of later banks can't be numbered without bank 0.
+			name:  "banks without bank 0 lanes",
+			b:     cmisDR4(0)[:cmisPage02Len],
+			banks: [][]byte{cmisDR4(1)},
+		},
+		{
+			name: "flat memory",
+			b:    flat,
+			c: func() *CMIS {
+				c := cmisDR4Want()
+				c.FlatMemory = true
+				c.Thresholds = nil
+				c.Lanes = nil
+				return c
+			}(),
+			ok: true,
+		},
+		{
+			name: "no lanes",
+			b:    cmisDR4(0)[:cmisPage02Len],
+			c: func() *CMIS {
+				c := cmisDR4Want()
+				c.Lanes = nil
+				return c
+			}(),
+			ok: true,
+		},
+		{
+			name: "400GBASE-DR4",
+			b:    cmisDR4(0),
+			c: func() *CMIS {
+				c := cmisDR4Want()
+				c.Lanes = c.Lanes[:8]
+				return c
+			}(),
+			ok: true,
+		},
+		{
+			// Lanes beyond those used by any application are absent.
+			name: "4 lanes",
+			b: func() []byte {
+				b := cmisDR4(0)
+				b[88] = 0x44
+				return b
+			}(),
+			c: func() *CMIS {
+				c := cmisDR4Want()
+				c.Applications[0].HostLaneCount = 4
+				c.Lanes = c.Lanes[:4]
+				return c
+			}(),
+			ok: true,
+		},
+		{
+			name:  "400GBASE-DR4 two banks",
+			b:     cmisDR4(0),
+			banks: [][]byte{cmisDR4(1)},
+			c:     cmisDR4Want(),
+			ok:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseCMIS(tt.b, tt.banks...)
+			if tt.ok && err != nil {
+				t.Fatalf("failed to parse CMIS: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+			if err != nil {
+				t.Logf("err: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.c, c); diff != "" {
+				t.Fatalf("unexpected CMIS (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadCMIS(t *testing.T) {
+	// Bank 0 may be read through upper page 02h and upper page 11h, while
+	// other banks only require upper page 11h.
+	banks := map[int]*pageReader{
+		0: {
+			b:     cmisDR4(0),
+			pages: map[int]bool{0: true, 1: true, 2: true, 3: true, 0x12: true},
+		},
+		1: {
+			b:     cmisDR4(1),
+			pages: map[int]bool{0x12: true},
+		},
+	}
+
+	c, err := ReadCMIS(func(n int) io.ReaderAt {
+		r, ok := banks[n]
+		if !ok {
+			t.Fatalf("unexpected bank: %d", n)
+		}
+
+		return r
+	})
+	if err != nil {
+		t.Fatalf("failed to read CMIS: %v", err)
+	}
+
+	if diff := cmp.Diff(cmisDR4Want(), c); diff != "" {
+		t.Fatalf("unexpected CMIS (-want +got):\n%s", diff)
+	}
+
+	// Flat memory modules only require the lower page and upper page 00h.
+	flat := cmisDR4(0)[:cmisLen]
+	flat[2] |= 1 << 7
+
+	c, err = ReadCMIS(func(n int) io.ReaderAt {
+		if n != 0 {
+			t.Fatalf("unexpected bank: %d", n)
+		}
+
+		return bytes.NewReader(flat)
+	})
+	if err != nil {
+		t.Fatalf("failed to read flat CMIS: %v", err)
+	}
+	if c.Lanes != nil {
+		t.Fatalf("expected no lanes, but got: %+v", c.Lanes)
+	}
+}
+
+// cmisDR4 returns the module memory of the specified bank of a 400GBASE-DR4
+// QSFP-DD module with 2 banks, through upper page 11h.
+func cmisDR4(bank int) []byte {
+	b := make([]byte, cmisPage11Len)
+	u16 := func(off int, v uint16) {
+		binary.BigEndian.PutUint16(b[off:], v)
+	}
+
+	// Lower page.
+	b[0] = byte(IdentifierQSFPDD)
+	b[1] = 0x50
+	b[3] = byte(ModuleStateReady) << 1
+	b[8] = 1 << 0
+	b[9] = 1<<2 | 1<<5
+	u16(14, 0x2100)
+	u16(16, 32900)
+	b[39], b[40] = 2, 7
+	b[85] = byte(MediaTypeSMF)
+	copy(b[86:], []byte{
+		0x11, 0x1c, 0x84, 0x01,
+		0x0d, 0x1e, 0x21, 0x55,
+	})
+	// The list of applications ends after application 2.
+	for i := 94; i < 118; i += 4 {
+		b[i] = 0xff
+	}
+
+	// Upper page 00h.
+	b[128] = byte(IdentifierQSFPDD)
+	copy(b[129:145], "INNOLIGHT       ")
+	copy(b[145:148], []byte{0x44, 0x7c, 0x7f})
+	copy(b[148:164], "T-DP4CNT-NCI    ")
+	copy(b[164:166], "1A")
+	copy(b[166:182], "INLBA1234       ")
+	copy(b[182:190], "21030801")
+	b[203] = byte(ConnectorMPO1x12)
+	for i := 128; i < 222; i++ {
+		b[222] += b[i]
+	}
+
+	// Upper page 01h: 2 banks and a TX bias multiplier of 2.
+	p01 := b[pageLen:]
+	p01[142] = 0x01
+	p01[160] = 0x01 << 3
+
+	// Upper page 02h thresholds.
+	p02 := b[2*pageLen:]
+	for off, vs := range map[int][4]uint16{
+		128: {80 << 8, 0xf600, 75 << 8, 0xfb00},
+		136: {36300, 29700, 34650, 31350},
+		176: {31623, 1122, 25119, 1413},
+		184: {7500, 1000, 7000, 1500},
+		192: {31623, 457, 25119, 575},
+	} {
+		for i, v := range vs {
+			binary.BigEndian.PutUint16(p02[off+2*i:], v)
+		}
+	}
+
+	// Upper page 11h lanes, which differ in each bank.
+	p11 := b[cmisPage11Off-pageLen:]
+	for i := range 4 {
+		p11[128+i] = byte(DataPathStateActivated)<<4 | byte(DataPathStateActivated)
+	}
+	if bank == 0 {
+		p11[128] = byte(DataPathStateActivated)<<4 | byte(DataPathStateInit)
+		p11[135] = 1 << 7
+		p11[136] = 1 << 6
+		p11[137] = 1 << 5
+		p11[141] = 1 << 0
+		p11[146] = 1 << 1
+		p11[147] = 1 << 2
+		p11[148] = 1 << 3
+		p11[149] = 1 << 4
+	}
+	for i := range 8 {
+		off := uint16(100*bank + i)
+		u16(cmisPage11Off-pageLen+154+2*i, 10000+off)
+		u16(cmisPage11Off-pageLen+170+2*i, 3000+off)
+		u16(cmisPage11Off-pageLen+186+2*i, 8000+off)
+	}
+
+	return b
+}
+
+// cmisDR4Want returns the expected CMIS for both banks of cmisDR4.
+func cmisDR4Want() *CMIS {
+	c := &CMIS{
+		Identifier:     IdentifierQSFPDD,
+		RevisionMajor:  5,
+		ModuleState:    ModuleStateReady,
+		ActiveFirmware: FirmwareVersion{Major: 2, Minor: 7},
+		MediaType:      MediaTypeSMF,
+		Connector:      ConnectorMPO1x12,
+		Vendor: Vendor{
+			Name:         "INNOLIGHT",
+			OUI:          [3]byte{0x44, 0x7c, 0x7f},
+			PartNumber:   "T-DP4CNT-NCI",
+			Revision:     "1A",
+			SerialNumber: "INLBA1234",
+			DateCode:     time.Date(2021, time.March, 8, 0, 0, 0, 0, time.UTC),
+			LotCode:      "01",
+		},
+		Applications: []Application{
+			{
+				HostInterfaceID:    0x11,
+				MediaInterfaceID:   0x1c,
+				HostLaneCount:      8,
+				MediaLaneCount:     4,
+				HostLaneAssignment: 0x01,
+			},
+			{
+				HostInterfaceID:    0x0d,
+				MediaInterfaceID:   0x1e,
+				HostLaneCount:      2,
+				MediaLaneCount:     1,
+				HostLaneAssignment: 0x55,
+			},
+		},
+		TemperatureCelsius: 33,
+		VoltageVolts:       3.29,
+		Flags: CMISModuleFlags{
+			StateChanged: true,
+			Temperature:  AlarmFlags{HighWarning: true},
+			Voltage:      AlarmFlags{LowAlarm: true},
+		},
+		Thresholds: &CMISThresholds{
+			TemperatureCelsius: Thresholds{HighAlarm: 80, LowAlarm: -10, HighWarning: 75, LowWarning: -5},
+			VoltageVolts:       Thresholds{HighAlarm: 3.63, LowAlarm: 2.97, HighWarning: 3.465, LowWarning: 3.135},
+			TXPowerMilliwatts:  Thresholds{HighAlarm: 3.1623, LowAlarm: 0.1122, HighWarning: 2.5119, LowWarning: 0.1413},
+			TXBiasMilliamps:    Thresholds{HighAlarm: 30, LowAlarm: 4, HighWarning: 28, LowWarning: 6},
+			RXPowerMilliwatts:  Thresholds{HighAlarm: 3.1623, LowAlarm: 0.0457, HighWarning: 2.5119, LowWarning: 0.0575},
+		},
+		BaseChecksumValid: true,
+	}
+
+	for bank := range 2 {
+		for i := range 8 {
+			off := float64(100*bank + i)
+			c.Lanes = append(c.Lanes, CMISLane{
+				DataPathState:     DataPathStateActivated,
+				TXPowerMilliwatts: (10000 + off) / 10000,
+				TXBiasMilliamps:   (3000 + off) * 2 / 1000 * 2,
+				RXPowerMilliwatts: (8000 + off) / 10000,
+			})
+		}
+	}
+
+	// Bank 0 lane 1 is initializing and each lane of bank 0 has a flag set.
+	l := c.Lanes
+	l[0].DataPathState = DataPathStateInit
+	l[7].TXFault = true
+	l[6].TXLOS = true
+	l[5].TXCDRLOL = true
+	l[0].TXPowerFlags.HighWarning = true
+	l[1].TXBiasFlags.LowWarning = true
+	l[2].RXLOS = true
+	l[3].RXCDRLOL = true
+	l[4].RXPowerFlags.HighAlarm = true
+
+	return c
+}
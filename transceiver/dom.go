@@ -0,0 +1,127 @@
+package transceiver
+
+import "math"
+
+// A DOM contains the calibrated digital optical monitoring values of a module,
+// each evaluated against the module's alarm and warning thresholds.
+type DOM struct {
+	TemperatureCelsius Reading
+	VoltageVolts       Reading
+
+	// Lanes contains the values of each lane, or the single lane of an
+	// SFF-8472 module.
+	Lanes []DOMLane
+}
+
+// A DOMLane contains the calibrated monitoring values of a single lane.
+type DOMLane struct {
+	TXBiasMilliamps   Reading
+	TXPowerMilliwatts Reading
+	RXPowerMilliwatts Reading
+
+	// TXPowerDBm and RXPowerDBm are the optical powers in dBm. A power of 0
+	// mW is reported as negative infinity.
+	TXPowerDBm float64
+	RXPowerDBm float64
+}
+
+// Severity returns the most severe Severity of all readings in the DOM.
+func (d *DOM) Severity() Severity {
+	s := max(d.TemperatureCelsius.Severity, d.VoltageVolts.Severity)
+	for _, l := range d.Lanes {
+		s = max(s, l.TXBiasMilliamps.Severity, l.TXPowerMilliwatts.Severity, l.RXPowerMilliwatts.Severity)
+	}
+
+	return s
+}
+
+// A Reading is a monitored value and the result of evaluating it against the
+// module's thresholds.
+type Reading struct {
+	Value float64
+
+	// Thresholds contains the thresholds used to evaluate Value, or nil if
+	// the module did not provide thresholds.
+	Thresholds *Thresholds
+	Severity   Severity
+}
+
+// A Severity is the result of evaluating a Reading against its thresholds.
+// Severities are ordered so that a more severe result compares greater than a
+// less severe one.
+type Severity int
+
+// Possible Severity values.
+const (
+	SeverityUnknown Severity = iota
+	SeverityOK
+	SeverityLowWarning
+	SeverityHighWarning
+	SeverityLowAlarm
+	SeverityHighAlarm
+)
+
+// String returns the string representation of a Severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityUnknown:
+		return "Unknown"
+	case SeverityOK:
+		return "OK"
+	case SeverityLowWarning:
+		return "LowWarning"
+	case SeverityHighWarning:
+		return "HighWarning"
+	case SeverityLowAlarm:
+		return "LowAlarm"
+	case SeverityHighAlarm:
+		return "HighAlarm"
+	default:
+		return "Invalid"
+	}
+}
+
+// newReading evaluates v against t, which may be nil if no thresholds are
+// available.
+func newReading(v float64, t *Thresholds) Reading {
+	r := Reading{Value: v}
+	if t == nil {
+		return r
+	}
+
+	// Copy the thresholds so the Reading does not alias its source.
+	tc := *t
+	r.Thresholds = &tc
+
+	switch {
+	case v > t.HighAlarm:
+		r.Severity = SeverityHighAlarm
+	case v < t.LowAlarm:
+		r.Severity = SeverityLowAlarm
+	case v > t.HighWarning:
+		r.Severity = SeverityHighWarning
+	case v < t.LowWarning:
+		r.Severity = SeverityLowWarning
+	default:
+		r.Severity = SeverityOK
+	}
+
+	return r
+}
+
+// newDOMLane creates a DOMLane from monitored values and their optional
+// thresholds.
+func newDOMLane(bias, tx, rx float64, biasT, txT, rxT *Thresholds) DOMLane {
+	return DOMLane{
+		TXBiasMilliamps:   newReading(bias, biasT),
+		TXPowerMilliwatts: newReading(tx, txT),
+		RXPowerMilliwatts: newReading(rx, rxT),
+		TXPowerDBm:        dBm(tx),
+		RXPowerDBm:        dBm(rx),
+	}
+}
+
+// dBm converts a power in milliwatts to dBm.
+func dBm(mW float64) float64 {
+	return 10 * math.Log10(mW)
+}
@@ -0,0 +1,321 @@
+package transceiver
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSFPDOM(t *testing.T) {
+	internal, err := ParseSFP(sfpSR())
+	if err != nil {
+		t.Fatalf("failed to parse SFP: %v", err)
+	}
+
+	external := *internal
+	external.Diagnostics.Calibration = CalibrationExternal
+
+	none := *internal
+	none.Diagnostics.Implemented = false
+
+	tests := []struct {
+		name string
+		sfp  *SFP
+		a2   []byte
+		d    *DOM
+		ok   bool
+	}{
+		{
+			name: "not implemented",
+			sfp:  &none,
+			a2:   sfpA2(),
+		},
+		{
+			name: "short",
+			sfp:  internal,
+			a2:   make([]byte, sfpDiagLen-1),
+		},
+		{
+			name: "internal",
+			sfp:  internal,
+			a2:   sfpA2(),
+			d: &DOM{
+				TemperatureCelsius: Reading{
+					Value:      16,
+					Thresholds: &Thresholds{HighAlarm: 32, LowAlarm: -4, HighWarning: 28, LowWarning: 0},
+					Severity:   SeverityOK,
+				},
+				VoltageVolts: Reading{
+					Value:      3.3,
+					Thresholds: &Thresholds{HighAlarm: 3.6, LowAlarm: 3, HighWarning: 3.5, LowWarning: 3.1},
+					Severity:   SeverityOK,
+				},
+				Lanes: []DOMLane{{
+					TXBiasMilliamps: Reading{
+						Value:      12,
+						Thresholds: &Thresholds{HighAlarm: 15, LowAlarm: 2, HighWarning: 11, LowWarning: 3},
+						Severity:   SeverityHighWarning,
+					},
+					TXPowerMilliwatts: Reading{
+						Value:      0.6,
+						Thresholds: &Thresholds{HighAlarm: 1, LowAlarm: 0.1, HighWarning: 0.8, LowWarning: 0.2},
+						Severity:   SeverityOK,
+					},
+					RXPowerMilliwatts: Reading{
+						Value:      0.2,
+						Thresholds: &Thresholds{HighAlarm: 1, LowAlarm: 0.05, HighWarning: 0.8, LowWarning: 0.3},
+						Severity:   SeverityLowWarning,
+					},
+					TXPowerDBm: 10 * math.Log10(0.6),
+					RXPowerDBm: 10 * math.Log10(0.2),
+				}},
+			},
+			ok: true,
+		},
+		{
+			name: "external",
+			sfp:  &external,
+			a2:   sfpA2(),
+			d: &DOM{
+				// Slope 2, offset 1 degree.
+				TemperatureCelsius: Reading{
+					Value:      33,
+					Thresholds: &Thresholds{HighAlarm: 65, LowAlarm: -7, HighWarning: 57, LowWarning: 1},
+					Severity:   SeverityOK,
+				},
+				// Slope 1, offset 0.
+				VoltageVolts: Reading{
+					Value:      3.3,
+					Thresholds: &Thresholds{HighAlarm: 3.6, LowAlarm: 3, HighWarning: 3.5, LowWarning: 3.1},
+					Severity:   SeverityOK,
+				},
+				Lanes: []DOMLane{{
+					// Slope 0.5, offset 100 units.
+					TXBiasMilliamps: Reading{
+						Value:      6.2,
+						Thresholds: &Thresholds{HighAlarm: 7.7, LowAlarm: 1.2, HighWarning: 5.7, LowWarning: 1.7},
+						Severity:   SeverityHighWarning,
+					},
+					// Slope 1, offset -1000 units.
+					TXPowerMilliwatts: Reading{
+						Value:      0.5,
+						Thresholds: &Thresholds{HighAlarm: 0.9, LowAlarm: 0, HighWarning: 0.7, LowWarning: 0.1},
+						Severity:   SeverityOK,
+					},
+					// 2x+100 units.
+					RXPowerMilliwatts: Reading{
+						Value:      0.41,
+						Thresholds: &Thresholds{HighAlarm: 2.01, LowAlarm: 0.11, HighWarning: 1.61, LowWarning: 0.61},
+						Severity:   SeverityLowWarning,
+					},
+					TXPowerDBm: 10 * math.Log10(0.5),
+					RXPowerDBm: 10 * math.Log10(0.41),
+				}},
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := tt.sfp.DOM(tt.a2)
+			if tt.ok && err != nil {
+				t.Fatalf("failed to parse DOM: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+			if err != nil {
+				t.Logf("err: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.d, d); diff != "" {
+				t.Fatalf("unexpected DOM (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(SeverityHighWarning, d.Severity()); diff != "" {
+				t.Fatalf("unexpected severity (-want +got):\n%s", diff)
+			}
+
+			rd, err := tt.sfp.ReadDOM(bytes.NewReader(tt.a2))
+			if err != nil {
+				t.Fatalf("failed to read DOM: %v", err)
+			}
+
+			if diff := cmp.Diff(d, rd); diff != "" {
+				t.Fatalf("unexpected read DOM (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestQSFPDOM(t *testing.T) {
+	// Drop channel 3's received power below its low alarm threshold.
+	b := qsfpSR4()
+	binary.BigEndian.PutUint16(b[38:], 5)
+
+	q, err := ParseQSFP(b)
+	if err != nil {
+		t.Fatalf("failed to parse QSFP: %v", err)
+	}
+
+	d := q.DOM()
+	if diff := cmp.Diff(SeverityLowAlarm, d.Severity()); diff != "" {
+		t.Fatalf("unexpected severity (-want +got):\n%s", diff)
+	}
+
+	want := DOMLane{
+		TXBiasMilliamps: Reading{
+			Value:      7.004,
+			Thresholds: &q.Thresholds.TXBiasMilliamps,
+			Severity:   SeverityOK,
+		},
+		TXPowerMilliwatts: Reading{
+			Value:      0.6002,
+			Thresholds: &q.Thresholds.TXPowerMilliwatts,
+			Severity:   SeverityOK,
+		},
+		RXPowerMilliwatts: Reading{
+			Value:      0.0005,
+			Thresholds: &q.Thresholds.RXPowerMilliwatts,
+			Severity:   SeverityLowAlarm,
+		},
+		TXPowerDBm: 10 * math.Log10(0.6002),
+		RXPowerDBm: 10 * math.Log10(0.0005),
+	}
+
+	if diff := cmp.Diff(want, d.Lanes[2]); diff != "" {
+		t.Fatalf("unexpected lane (-want +got):\n%s", diff)
+	}
+
+	// Without thresholds, no evaluation is possible.
+	q.Thresholds = nil
+	if diff := cmp.Diff(SeverityUnknown, q.DOM().Severity()); diff != "" {
+		t.Fatalf("unexpected severity (-want +got):\n%s", diff)
+	}
+}
+
+func TestCMISDOM(t *testing.T) {
+	// Raise bank 1 lane 1's transmit power above its high warning threshold.
+	b1 := cmisDR4(1)
+	binary.BigEndian.PutUint16(b1[cmisPage11Off-pageLen+154:], 30000)
+
+	c, err := ParseCMIS(cmisDR4(0), b1)
+	if err != nil {
+		t.Fatalf("failed to parse CMIS: %v", err)
+	}
+
+	d := c.DOM()
+
+	var sevs []Severity
+	for _, l := range d.Lanes {
+		sevs = append(sevs, l.TXPowerMilliwatts.Severity)
+	}
+
+	// Only the 4 media lanes of each bank are monitored.
+	want := []Severity{
+		SeverityOK, SeverityOK, SeverityOK, SeverityOK,
+		SeverityHighWarning, SeverityOK, SeverityOK, SeverityOK,
+	}
+	if diff := cmp.Diff(want, sevs); diff != "" {
+		t.Fatalf("unexpected severities (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(SeverityHighWarning, d.Severity()); diff != "" {
+		t.Fatalf("unexpected severity (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(10*math.Log10(3), d.Lanes[4].TXPowerDBm); diff != "" {
+		t.Fatalf("unexpected TX power (-want +got):\n%s", diff)
+	}
+}
+
+func TestCMISDOMUnusedLanes(t *testing.T) {
+	tests := []struct {
+		name  string
+		lanes byte
+	}{
+		{
+			name:  "8 host lanes, 4 media lanes",
+			lanes: 0x84,
+		},
+		{
+			name:  "4 host lanes, 4 media lanes",
+			lanes: 0x44,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Lanes 5 through 8 are unused and have no monitored values.
+			b := cmisDR4(0)
+			b[88] = tt.lanes
+			for i := 4; i < 8; i++ {
+				for _, off := range []int{154, 170, 186} {
+					binary.BigEndian.PutUint16(b[cmisPage11Off-pageLen+off+2*i:], 0)
+				}
+			}
+
+			c, err := ParseCMIS(b)
+			if err != nil {
+				t.Fatalf("failed to parse CMIS: %v", err)
+			}
+
+			d := c.DOM()
+			if diff := cmp.Diff(4, len(d.Lanes)); diff != "" {
+				t.Fatalf("unexpected number of lanes (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(SeverityOK, d.Severity()); diff != "" {
+				t.Fatalf("unexpected severity (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// sfpA2 returns the A2h EEPROM of an SFF-8472 module, with values suitable for
+// either internal or external calibration.
+func sfpA2() []byte {
+	b := make([]byte, pageLen)
+	u16 := func(off int, v uint16) {
+		binary.BigEndian.PutUint16(b[off:], v)
+	}
+
+	// Thresholds.
+	for off, vs := range map[int][4]uint16{
+		0:  {0x2000, 0xfc00, 0x1c00, 0},
+		8:  {36000, 30000, 35000, 31000},
+		16: {7500, 1000, 5500, 1500},
+		24: {10000, 1000, 8000, 2000},
+		32: {10000, 500, 8000, 3000},
+	} {
+		for i, v := range vs {
+			u16(off+2*i, v)
+		}
+	}
+
+	// External calibration constants: received power is 2x+100.
+	binary.BigEndian.PutUint32(b[68:], math.Float32bits(2))
+	binary.BigEndian.PutUint32(b[72:], math.Float32bits(100))
+	u16(76, 0x0080)
+	u16(78, 100)
+	u16(80, 0x0100)
+	u16(82, 0xfc18)
+	u16(84, 0x0200)
+	u16(86, 0x0100)
+	u16(88, 0x0100)
+	u16(90, 0)
+
+	// Monitored values.
+	u16(96, 0x1000)
+	u16(98, 33000)
+	u16(100, 6000)
+	u16(102, 6000)
+	u16(104, 2000)
+
+	return b
+}
@@ -0,0 +1,317 @@
+package transceiver
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// An SFP contains the serial ID data of an SFF-8472 module, such as an SFP,
+// SFP+, or SFP28 module, as stored in the A0h EEPROM.
+type SFP struct {
+	Identifier    Identifier
+	ExtIdentifier uint8
+	Connector     Connector
+
+	// ComplianceCodes contains the raw transceiver compliance code bytes,
+	// and Compliance contains the names of the Ethernet and cable
+	// compliance codes which are set.
+	ComplianceCodes    [8]byte
+	Compliance         []string
+	ExtendedCompliance ExtendedCompliance
+
+	Encoding           uint8
+	NominalBitRateMbps int
+	Lengths            Lengths
+	Vendor             Vendor
+
+	// WavelengthNanometers is the nominal laser wavelength of an optical
+	// module, or 0 for copper cables.
+	WavelengthNanometers int
+
+	Diagnostics SFPDiagnostics
+
+	// SFF8472Compliance is the raw SFF-8472 revision compliance code. 0
+	// indicates that digital diagnostics are not included.
+	SFF8472Compliance uint8
+
+	// Checksums of the base and extended ID fields.
+	BaseChecksumValid     bool
+	ExtendedChecksumValid bool
+}
+
+// SFPDiagnostics describes the digital diagnostic monitoring (DDM) support of
+// an SFF-8472 module.
+type SFPDiagnostics struct {
+	// Implemented reports whether the module provides diagnostic
+	// monitoring in its A2h EEPROM.
+	Implemented bool
+	Calibration Calibration
+
+	// RXPowerAverage reports whether received power is measured as average
+	// power rather than OMA.
+	RXPowerAverage bool
+
+	// AddressChangeRequired reports whether the host must perform an
+	// address change sequence to access the A2h EEPROM.
+	AddressChangeRequired bool
+}
+
+// A Calibration is the calibration type of SFF-8472 diagnostic values.
+type Calibration int
+
+// Possible Calibration values.
+const (
+	CalibrationUnknown Calibration = iota
+	CalibrationInternal
+	CalibrationExternal
+)
+
+// String returns the string representation of a Calibration.
+func (c Calibration) String() string {
+	switch c {
+	case CalibrationUnknown:
+		return "Unknown"
+	case CalibrationInternal:
+		return "Internal"
+	case CalibrationExternal:
+		return "External"
+	default:
+		return "Invalid"
+	}
+}
+
+// sfpIDLen is the length of the SFF-8472 base and extended ID fields.
+const sfpIDLen = 96
+
+// ReadSFP reads and parses the A0h EEPROM of an SFF-8472 module from r.
+func ReadSFP(r io.ReaderAt) (*SFP, error) {
+	a0 := make([]byte, pageLen)
+	if err := readAll(r, a0, 0); err != nil {
+		return nil, fmt.Errorf("transceiver: failed to read SFP A0h EEPROM: %w", err)
+	}
+
+	return ParseSFP(a0)
+}
+
+// ParseSFP parses the A0h EEPROM of an SFF-8472 module. a0 must contain at
+// least the 96 bytes of the base and extended ID fields.
+func ParseSFP(a0 []byte) (*SFP, error) {
+	if err := checkLen(a0, sfpIDLen, "SFP A0h EEPROM"); err != nil {
+		return nil, err
+	}
+
+	id := Identifier(a0[0])
+	switch id {
+	case IdentifierGBIC, IdentifierSoldered, IdentifierSFP:
+	default:
+		return nil, fmt.Errorf("transceiver: identifier %s is not an SFF-8472 module", id)
+	}
+
+	sfp := &SFP{
+		Identifier:         id,
+		ExtIdentifier:      a0[1],
+		Connector:          Connector(a0[2]),
+		ExtendedCompliance: ExtendedCompliance(a0[36]),
+		Encoding:           a0[11],
+		Vendor: Vendor{
+			Name:         str(a0[20:36]),
+			PartNumber:   str(a0[40:56]),
+			Revision:     str(a0[56:60]),
+			SerialNumber: str(a0[68:84]),
+		},
+		Diagnostics: SFPDiagnostics{
+			Implemented:           a0[92]&(1<<6) != 0,
+			RXPowerAverage:        a0[92]&(1<<3) != 0,
+			AddressChangeRequired: a0[92]&(1<<2) != 0,
+		},
+		SFF8472Compliance:     a0[94],
+		BaseChecksumValid:     checksum(a0[0:63], a0[63]),
+		ExtendedChecksumValid: checksum(a0[64:95], a0[95]),
+	}
+
+	copy(sfp.ComplianceCodes[:], a0[3:11])
+	sfp.Compliance = compliance(sfp.ComplianceCodes[:], sfpComplianceNames)
+
+	copy(sfp.Vendor.OUI[:], a0[37:40])
+	sfp.Vendor.DateCode, sfp.Vendor.LotCode = dateCode(a0[84:92])
+
+	// A nominal rate of FFh indicates the rate is stored in units of 250
+	// MBd in the extended ID fields.
+	if a0[12] == 0xff {
+		sfp.NominalBitRateMbps = int(a0[66]) * 250
+	} else {
+		sfp.NominalBitRateMbps = int(a0[12]) * 100
+	}
+
+	// Copper cables reuse the OM4 length and wavelength fields.
+	cable := sfpCable(sfp.ComplianceCodes)
+
+	sfp.Lengths = Lengths{
+		OM1Meters: int(a0[17]) * 10,
+		OM2Meters: int(a0[16]) * 10,
+		OM3Meters: int(a0[19]) * 10,
+	}
+	if a0[15] != 0 && a0[15] != 0xff {
+		sfp.Lengths.SMFMeters = int(a0[15]) * 100
+	} else {
+		sfp.Lengths.SMFMeters = int(a0[14]) * 1000
+	}
+	if cable {
+		sfp.Lengths.CopperMeters = int(a0[18])
+	} else {
+		sfp.Lengths.OM4Meters = int(a0[18]) * 10
+		sfp.WavelengthNanometers = int(binary.BigEndian.Uint16(a0[60:62]))
+	}
+
+	switch {
+	case a0[92]&(1<<5) != 0:
+		sfp.Diagnostics.Calibration = CalibrationInternal
+	case a0[92]&(1<<4) != 0:
+		sfp.Diagnostics.Calibration = CalibrationExternal
+	}
+
+	return sfp, nil
+}
+
+// sfpDiagLen is the length of the SFF-8472 A2h diagnostic fields through the
+// real-time monitored values.
+const sfpDiagLen = 106
+
+// ReadDOM reads the A2h EEPROM of an SFF-8472 module from r and returns its
+// calibrated monitoring values. See DOM for details.
+func (s *SFP) ReadDOM(r io.ReaderAt) (*DOM, error) {
+	a2 := make([]byte, pageLen)
+	if err := readAll(r, a2, 0); err != nil {
+		return nil, fmt.Errorf("transceiver: failed to read SFP A2h EEPROM: %w", err)
+	}
+
+	return s.DOM(a2)
+}
+
+// DOM parses the A2h EEPROM of an SFF-8472 module and returns its monitoring
+// values, evaluated against the module's thresholds. If the SFP indicates that
+// it is externally calibrated, the calibration constants in a2 are applied to
+// both the monitored values and the thresholds.
+//
+// a2 must contain at least the 106 bytes of the A2h EEPROM which precede the
+// status and flag fields.
+func (s *SFP) DOM(a2 []byte) (*DOM, error) {
+	if !s.Diagnostics.Implemented {
+		return nil, errors.New("transceiver: SFP does not implement digital diagnostics")
+	}
+	if err := checkLen(a2, sfpDiagLen, "SFP A2h EEPROM"); err != nil {
+		return nil, err
+	}
+
+	dec := sfpDecoders{
+		temperature: temperature,
+		voltage:     voltage,
+		txBias:      bias,
+		txPower:     power,
+		rxPower:     power,
+	}
+	if s.Diagnostics.Calibration == CalibrationExternal {
+		dec = sfpExternal(a2)
+	}
+
+	var (
+		temp = thresholds(a2[0:8], dec.temperature)
+		vcc  = thresholds(a2[8:16], dec.voltage)
+		bias = thresholds(a2[16:24], dec.txBias)
+		tx   = thresholds(a2[24:32], dec.txPower)
+		rx   = thresholds(a2[32:40], dec.rxPower)
+	)
+
+	return &DOM{
+		TemperatureCelsius: newReading(dec.temperature(a2[96:98]), &temp),
+		VoltageVolts:       newReading(dec.voltage(a2[98:100]), &vcc),
+		Lanes: []DOMLane{newDOMLane(
+			dec.txBias(a2[100:102]), dec.txPower(a2[102:104]), dec.rxPower(a2[104:106]),
+			&bias, &tx, &rx,
+		)},
+	}, nil
+}
+
+// sfpDecoders decode the SFF-8472 A2h monitored values and thresholds.
+type sfpDecoders struct {
+	temperature, voltage, txBias, txPower, rxPower func([]byte) float64
+}
+
+// sfpExternal returns sfpDecoders which apply the SFF-8472 external
+// calibration constants in a2 to raw A/D values.
+func sfpExternal(a2 []byte) sfpDecoders {
+	u16 := func(b []byte) float64 {
+		return float64(binary.BigEndian.Uint16(b))
+	}
+
+	// Each linear calibration consists of an unsigned 8.8 fixed point slope
+	// followed by a signed offset, and produces a value in the same units as
+	// the internally calibrated value.
+	linear := func(off int, ad float64) float64 {
+		slope := u16(a2[off:]) / 256
+		offset := float64(int16(binary.BigEndian.Uint16(a2[off+2:])))
+		return slope*ad + offset
+	}
+
+	// Received power is calibrated by a fourth order polynomial with IEEE
+	// 754 coefficients, beginning with the highest order.
+	var rx [5]float64
+	for i := range rx {
+		rx[len(rx)-1-i] = float64(math.Float32frombits(binary.BigEndian.Uint32(a2[56+4*i:])))
+	}
+
+	// Bias and optical power cannot be negative.
+	nonNegative := func(v float64) float64 {
+		return max(v, 0)
+	}
+
+	return sfpDecoders{
+		temperature: func(b []byte) float64 {
+			return linear(84, float64(int16(binary.BigEndian.Uint16(b)))) / 256
+		},
+		voltage: func(b []byte) float64 {
+			return linear(88, u16(b)) / 10000
+		},
+		txBias: func(b []byte) float64 {
+			return nonNegative(linear(76, u16(b))) * 2 / 1000
+		},
+		txPower: func(b []byte) float64 {
+			return nonNegative(linear(80, u16(b))) / 10000
+		},
+		rxPower: func(b []byte) float64 {
+			ad := u16(b)
+			var v float64
+			for i := len(rx) - 1; i >= 0; i-- {
+				v = v*ad + rx[i]
+			}
+			return nonNegative(v) / 10000
+		},
+	}
+}
+
+// sfpCable reports whether SFP+ cable technology compliance bits are set.
+func sfpCable(codes [8]byte) bool {
+	return codes[5]&(1<<3|1<<2) != 0
+}
+
+// sfpComplianceNames maps SFF-8472 compliance code byte offsets and bits to
+// the names of Ethernet interfaces and cable technologies.
+var sfpComplianceNames = []complianceName{
+	{0, 7, "10GBASE-ER"},
+	{0, 6, "10GBASE-LRM"},
+	{0, 5, "10GBASE-LR"},
+	{0, 4, "10GBASE-SR"},
+	{3, 7, "BASE-PX"},
+	{3, 6, "BASE-BX10"},
+	{3, 5, "100BASE-FX"},
+	{3, 4, "100BASE-LX/LX10"},
+	{3, 3, "1000BASE-T"},
+	{3, 2, "1000BASE-CX"},
+	{3, 1, "1000BASE-LX"},
+	{3, 0, "1000BASE-SX"},
+	{5, 3, "Active Cable"},
+	{5, 2, "Passive Cable"},
+}
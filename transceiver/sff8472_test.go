@@ -0,0 +1,188 @@
+package transceiver
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseSFP(t *testing.T) {
+	tests := []struct {
+		name string
+		a0   []byte
+		sfp  *SFP
+		ok   bool
+	}{
+		{
+			name: "short",
+			a0:   make([]byte, 95),
+		},
+		{
+			name: "not SFP",
+			a0: func() []byte {
+				b := make([]byte, 128)
+				b[0] = byte(IdentifierQSFP28)
+				return b
+			}(),
+		},
+		{
+			name: "10GBASE-SR",
+			a0:   sfpSR(),
+			sfp: &SFP{
+				Identifier:           IdentifierSFP,
+				ExtIdentifier:        0x04,
+				Connector:            ConnectorLC,
+				ComplianceCodes:      [8]byte{0x10},
+				Compliance:           []string{"10GBASE-SR"},
+				Encoding:             0x06,
+				NominalBitRateMbps:   10300,
+				Lengths:              Lengths{OM1Meters: 30, OM2Meters: 80, OM3Meters: 300},
+				WavelengthNanometers: 850,
+				Vendor: Vendor{
+					Name:         "FINISAR CORP.",
+					OUI:          [3]byte{0x00, 0x90, 0x65},
+					PartNumber:   "FTLX8571D3BCL",
+					Revision:     "A",
+					SerialNumber: "ALN0Q9G",
+					DateCode:     time.Date(2013, time.May, 21, 0, 0, 0, 0, time.UTC),
+				},
+				Diagnostics: SFPDiagnostics{
+					Implemented:    true,
+					Calibration:    CalibrationInternal,
+					RXPowerAverage: true,
+				},
+				SFF8472Compliance:     0x03,
+				BaseChecksumValid:     true,
+				ExtendedChecksumValid: true,
+			},
+			ok: true,
+		},
+		{
+			name: "passive cable",
+			a0: func() []byte {
+				b := make([]byte, 96)
+				b[0] = byte(IdentifierSFP)
+				b[2] = byte(ConnectorCopperPigtail)
+				b[8] = 1 << 2
+				b[12] = 0xff
+				b[18] = 3
+				b[36] = byte(ExtendedCompliance25GBASECRS)
+				b[60] = 0x01
+				b[66] = 103
+				copy(b[84:92], "99999901")
+				return b
+			}(),
+			sfp: &SFP{
+				Identifier:         IdentifierSFP,
+				Connector:          ConnectorCopperPigtail,
+				ComplianceCodes:    [8]byte{5: 1 << 2},
+				Compliance:         []string{"Passive Cable"},
+				ExtendedCompliance: ExtendedCompliance25GBASECRS,
+				NominalBitRateMbps: 25750,
+				Lengths:            Lengths{CopperMeters: 3},
+				Vendor:             Vendor{LotCode: "01"},
+			},
+			ok: true,
+		},
+		{
+			name: "long reach external calibration",
+			a0: func() []byte {
+				b := make([]byte, 96)
+				b[0] = byte(IdentifierSFP)
+				b[3] = 1 << 5
+				b[14] = 10
+				b[15] = 0xff
+				b[60], b[61] = 0x05, 0x1e
+				b[92] = 1<<6 | 1<<4 | 1<<2
+				return b
+			}(),
+			sfp: &SFP{
+				Identifier:           IdentifierSFP,
+				ComplianceCodes:      [8]byte{1 << 5},
+				Compliance:           []string{"10GBASE-LR"},
+				Lengths:              Lengths{SMFMeters: 10000},
+				WavelengthNanometers: 1310,
+				Diagnostics: SFPDiagnostics{
+					Implemented:           true,
+					Calibration:           CalibrationExternal,
+					AddressChangeRequired: true,
+				},
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sfp, err := ParseSFP(tt.a0)
+			if tt.ok && err != nil {
+				t.Fatalf("failed to parse SFP: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+			if err != nil {
+				t.Logf("err: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.sfp, sfp); diff != "" {
+				t.Fatalf("unexpected SFP (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadSFP(t *testing.T) {
+	want, err := ParseSFP(sfpSR())
+	if err != nil {
+		t.Fatalf("failed to parse SFP: %v", err)
+	}
+
+	got, err := ReadSFP(bytes.NewReader(sfpSR()))
+	if err != nil {
+		t.Fatalf("failed to read SFP: %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected SFP (-want +got):\n%s", diff)
+	}
+
+	if _, err := ReadSFP(bytes.NewReader(sfpSR()[:64])); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("expected unexpected EOF, but got: %v", err)
+	}
+}
+
+// sfpSR returns the A0h EEPROM of a typical 10GBASE-SR SFP+ module.
+func sfpSR() []byte {
+	b := make([]byte, 128)
+	b[0] = byte(IdentifierSFP)
+	b[1] = 0x04
+	b[2] = byte(ConnectorLC)
+	b[3] = 1 << 4
+	b[11] = 0x06
+	b[12] = 103
+	b[16], b[17], b[19] = 8, 3, 30
+	copy(b[20:36], "FINISAR CORP.   ")
+	copy(b[37:40], []byte{0x00, 0x90, 0x65})
+	copy(b[40:56], "FTLX8571D3BCL   ")
+	copy(b[56:60], "A   ")
+	b[60], b[61] = 0x03, 0x52
+	copy(b[68:84], "ALN0Q9G         ")
+	copy(b[84:92], "130521  ")
+	b[92] = 1<<6 | 1<<5 | 1<<3
+	b[94] = 0x03
+
+	for i := range 63 {
+		b[63] += b[i]
+	}
+	for i := 64; i < 95; i++ {
+		b[95] += b[i]
+	}
+
+	return b
+}
@@ -0,0 +1,273 @@
+package transceiver
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// A QSFP contains the identification and diagnostic data of an SFF-8636 or
+// SFF-8436 module, such as a QSFP+ or QSFP28 module.
+type QSFP struct {
+	Identifier         Identifier
+	RevisionCompliance uint8
+
+	// FlatMemory reports whether the module only implements the lower page
+	// and upper page 00h. DataNotReady reports whether the module has not
+	// yet finished initializing its monitored data.
+	FlatMemory   bool
+	DataNotReady bool
+
+	ExtIdentifier uint8
+	Connector     Connector
+
+	// ComplianceCodes contains the raw specification compliance code bytes,
+	// and Compliance contains the names of the Ethernet compliance codes
+	// which are set.
+	ComplianceCodes    [8]byte
+	Compliance         []string
+	ExtendedCompliance ExtendedCompliance
+
+	Encoding           uint8
+	NominalBitRateMbps int
+	Lengths            Lengths
+	Vendor             Vendor
+
+	// WavelengthNanometers is the nominal laser wavelength of an optical
+	// module, or 0 for copper cables.
+	WavelengthNanometers float64
+
+	// RXPowerAverage reports whether received power is measured as average
+	// power rather than OMA.
+	RXPowerAverage bool
+
+	// Checksums of the base and extended ID fields.
+	BaseChecksumValid     bool
+	ExtendedChecksumValid bool
+
+	// Module level monitored values and flags.
+	TemperatureCelsius float64
+	VoltageVolts       float64
+	TemperatureFlags   AlarmFlags
+	VoltageFlags       AlarmFlags
+
+	Channels [4]QSFPChannel
+
+	// Thresholds contains the alarm and warning thresholds from upper page
+	// 03h. Thresholds is nil for flat memory modules or if upper page 03h
+	// was not provided.
+	Thresholds *QSFPThresholds
+}
+
+// A QSFPChannel contains the monitored values and flags of a single QSFP
+// channel.
+type QSFPChannel struct {
+	RXPowerMilliwatts float64
+	TXBiasMilliamps   float64
+	TXPowerMilliwatts float64
+
+	RXPowerFlags AlarmFlags
+	TXBiasFlags  AlarmFlags
+	TXPowerFlags AlarmFlags
+
+	// Latched loss of signal, fault, and CDR loss of lock indicators.
+	RXLOS    bool
+	TXLOS    bool
+	TXFault  bool
+	RXCDRLOL bool
+	TXCDRLOL bool
+}
+
+// QSFPThresholds contains the alarm and warning thresholds of a QSFP module.
+type QSFPThresholds struct {
+	TemperatureCelsius Thresholds
+	VoltageVolts       Thresholds
+	RXPowerMilliwatts  Thresholds
+	TXBiasMilliamps    Thresholds
+	TXPowerMilliwatts  Thresholds
+}
+
+// Lengths of the regions of QSFP module memory used by the decoder.
+const (
+	// qsfpLen is the length of the lower page and upper page 00h.
+	qsfpLen = 2 * pageLen
+	// qsfpPage03Len is the length of module memory through upper page 03h.
+	qsfpPage03Len = 5 * pageLen
+)
+
+// ReadQSFP reads and parses the EEPROM of an SFF-8636 or SFF-8436 module from
+// r. Upper page 03h is only read if the module supports paging.
+func ReadQSFP(r io.ReaderAt) (*QSFP, error) {
+	b := make([]byte, qsfpLen, qsfpPage03Len)
+	if err := readAll(r, b, 0); err != nil {
+		return nil, fmt.Errorf("transceiver: failed to read QSFP EEPROM: %w", err)
+	}
+
+	if !qsfpFlatMemory(b) {
+		b = b[:qsfpPage03Len]
+		if err := readAll(r, b[4*pageLen:], 4*pageLen); err != nil {
+			return nil, fmt.Errorf("transceiver: failed to read QSFP upper page 03h: %w", err)
+		}
+	}
+
+	return ParseQSFP(b)
+}
+
+// ParseQSFP parses the EEPROM of an SFF-8636 or SFF-8436 module. b must
+// contain at least the lower page and upper page 00h, laid out as a flat
+// address space. If b also contains upper pages 01h through 03h, thresholds
+// are parsed from upper page 03h.
+func ParseQSFP(b []byte) (*QSFP, error) {
+	if err := checkLen(b, qsfpLen, "QSFP EEPROM"); err != nil {
+		return nil, err
+	}
+
+	id := Identifier(b[0])
+	switch id {
+	case IdentifierQSFP, IdentifierQSFPPlus, IdentifierQSFP28:
+	default:
+		return nil, fmt.Errorf("transceiver: identifier %s is not an SFF-8636 module", id)
+	}
+
+	q := &QSFP{
+		Identifier:         id,
+		RevisionCompliance: b[1],
+		FlatMemory:         qsfpFlatMemory(b),
+		DataNotReady:       b[2]&(1<<0) != 0,
+		ExtIdentifier:      b[129],
+		Connector:          Connector(b[130]),
+		Encoding:           b[139],
+		Vendor: Vendor{
+			Name:         str(b[148:164]),
+			PartNumber:   str(b[168:184]),
+			Revision:     str(b[184:186]),
+			SerialNumber: str(b[196:212]),
+		},
+		RXPowerAverage:        b[220]&(1<<3) != 0,
+		BaseChecksumValid:     checksum(b[128:191], b[191]),
+		ExtendedChecksumValid: checksum(b[192:223], b[223]),
+		TemperatureCelsius:    temperature(b[22:24]),
+		VoltageVolts:          voltage(b[26:28]),
+		TemperatureFlags:      alarmFlags(b[6] >> 4),
+		VoltageFlags:          alarmFlags(b[7] >> 4),
+	}
+
+	copy(q.ComplianceCodes[:], b[131:139])
+	q.Compliance = compliance(q.ComplianceCodes[:], qsfpComplianceNames)
+
+	// The extended compliance code is only valid when indicated by the
+	// Ethernet compliance codes.
+	if b[131]&(1<<7) != 0 {
+		q.ExtendedCompliance = ExtendedCompliance(b[192])
+	}
+
+	copy(q.Vendor.OUI[:], b[165:168])
+	q.Vendor.DateCode, q.Vendor.LotCode = dateCode(b[212:220])
+
+	// A nominal rate of FFh indicates the rate is stored in units of 250
+	// MBd in the extended ID fields.
+	if b[140] == 0xff {
+		q.NominalBitRateMbps = int(b[222]) * 250
+	} else {
+		q.NominalBitRateMbps = int(b[140]) * 100
+	}
+
+	// Copper cables reuse the OM4 length and wavelength fields.
+	copper := b[147]>>4 >= 0x0a
+
+	q.Lengths = Lengths{
+		SMFMeters: int(b[142]) * 1000,
+		OM3Meters: int(b[143]) * 2,
+		OM2Meters: int(b[144]),
+		OM1Meters: int(b[145]),
+	}
+	if copper {
+		q.Lengths.CopperMeters = int(b[146])
+	} else {
+		q.Lengths.OM4Meters = int(b[146]) * 2
+		q.WavelengthNanometers = float64(binary.BigEndian.Uint16(b[186:188])) / 20
+	}
+
+	for i := range q.Channels {
+		// Each flag byte holds the flags of 2 channels in descending nibbles,
+		// while the indicator bytes hold 1 bit per channel.
+		flags := func(off int) AlarmFlags {
+			return alarmFlags(b[off+i/2] >> (4 * (1 - i%2)))
+		}
+
+		q.Channels[i] = QSFPChannel{
+			RXPowerMilliwatts: power(b[34+2*i:]),
+			TXBiasMilliamps:   bias(b[42+2*i:]),
+			TXPowerMilliwatts: power(b[50+2*i:]),
+			RXPowerFlags:      flags(9),
+			TXBiasFlags:       flags(11),
+			TXPowerFlags:      flags(13),
+			RXLOS:             b[3]&(1<<i) != 0,
+			TXLOS:             b[3]&(1<<(4+i)) != 0,
+			TXFault:           b[4]&(1<<i) != 0,
+			RXCDRLOL:          b[5]&(1<<i) != 0,
+			TXCDRLOL:          b[5]&(1<<(4+i)) != 0,
+		}
+	}
+
+	if !q.FlatMemory && len(b) >= qsfpPage03Len {
+		// Upper page 03h, indexed by offsets within the 256 bytes formed by
+		// the lower page and the selected upper page.
+		p := b[3*pageLen : qsfpPage03Len]
+		q.Thresholds = &QSFPThresholds{
+			TemperatureCelsius: thresholds(p[128:], temperature),
+			VoltageVolts:       thresholds(p[144:], voltage),
+			RXPowerMilliwatts:  thresholds(p[176:], power),
+			TXBiasMilliamps:    thresholds(p[184:], bias),
+			TXPowerMilliwatts:  thresholds(p[192:], power),
+		}
+	}
+
+	return q, nil
+}
+
+// DOM returns the monitoring values of the QSFP, evaluated against its
+// thresholds if available.
+func (q *QSFP) DOM() *DOM {
+	var temp, vcc, bias, tx, rx *Thresholds
+	if t := q.Thresholds; t != nil {
+		temp, vcc = &t.TemperatureCelsius, &t.VoltageVolts
+		bias, tx, rx = &t.TXBiasMilliamps, &t.TXPowerMilliwatts, &t.RXPowerMilliwatts
+	}
+
+	d := &DOM{
+		TemperatureCelsius: newReading(q.TemperatureCelsius, temp),
+		VoltageVolts:       newReading(q.VoltageVolts, vcc),
+		Lanes:              make([]DOMLane, 0, len(q.Channels)),
+	}
+	for _, ch := range q.Channels {
+		d.Lanes = append(d.Lanes, newDOMLane(
+			ch.TXBiasMilliamps, ch.TXPowerMilliwatts, ch.RXPowerMilliwatts,
+			bias, tx, rx,
+		))
+	}
+
+	return d
+}
+
+// qsfpFlatMemory reports whether a QSFP module only implements the lower page
+// and upper page 00h.
+func qsfpFlatMemory(b []byte) bool {
+	return b[2]&(1<<2) != 0
+}
+
+// qsfpComplianceNames maps SFF-8636 compliance code byte offsets and bits to
+// the names of Ethernet interfaces.
+var qsfpComplianceNames = []complianceName{
+	{0, 6, "10GBASE-LRM"},
+	{0, 5, "10GBASE-LR"},
+	{0, 4, "10GBASE-SR"},
+	{0, 3, "40GBASE-CR4"},
+	{0, 2, "40GBASE-SR4"},
+	{0, 1, "40GBASE-LR4"},
+	{0, 0, "40G Active Cable"},
+	{3, 3, "1000BASE-T"},
+	{3, 2, "1000BASE-CX"},
+	{3, 1, "1000BASE-LX"},
+	{3, 0, "1000BASE-SX"},
+}
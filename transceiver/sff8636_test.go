@@ -0,0 +1,292 @@
+package transceiver
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseQSFP(t *testing.T) {
+	// The same module, but with flat memory and no upper page 03h.
+	flat := qsfpSR4()[:qsfpLen]
+	flat[2] |= 1 << 2
+
+	tests := []struct {
+		name string
+		b    []byte
+		q    *QSFP
+		ok   bool
+	}{
+		{
+			name: "short",
+			b:    make([]byte, 255),
+		},
+		{
+			name: "not QSFP",
+			b: func() []byte {
+				b := make([]byte, qsfpLen)
+				b[0] = byte(IdentifierSFP)
+				return b
+			}(),
+		},
+		{
+			name: "100GBASE-SR4",
+			b:    qsfpSR4(),
+			q: func() *QSFP {
+				q := qsfpSR4Want()
+				q.Thresholds = &QSFPThresholds{
+					TemperatureCelsius: Thresholds{HighAlarm: 75, LowAlarm: -5, HighWarning: 70, LowWarning: 0},
+					VoltageVolts:       Thresholds{HighAlarm: 3.6, LowAlarm: 3, HighWarning: 3.5, LowWarning: 3.1},
+					RXPowerMilliwatts:  Thresholds{HighAlarm: 3.4673, LowAlarm: 0.0407, HighWarning: 1.7378, LowWarning: 0.0813},
+					TXBiasMilliamps:    Thresholds{HighAlarm: 13, LowAlarm: 3, HighWarning: 12, LowWarning: 4},
+					TXPowerMilliwatts:  Thresholds{HighAlarm: 2.8183, LowAlarm: 0.0724, HighWarning: 1.4125, LowWarning: 0.1445},
+				}
+				return q
+			}(),
+			ok: true,
+		},
+		{
+			name: "flat memory",
+			b:    flat,
+			q: func() *QSFP {
+				q := qsfpSR4Want()
+				q.FlatMemory = true
+				return q
+			}(),
+			ok: true,
+		},
+		{
+			name: "copper",
+			b: func() []byte {
+				b := make([]byte, qsfpLen)
+				b[0] = byte(IdentifierQSFP28)
+				b[130] = byte(ConnectorNoSeparable)
+				b[131] = 1 << 7
+				b[140] = 0xff
+				b[146] = 2
+				b[147] = 0xa0
+				b[186] = 0x01
+				b[192] = byte(ExtendedCompliance100GBASECR4)
+				b[222] = 103
+				return b
+			}(),
+			q: &QSFP{
+				Identifier:         IdentifierQSFP28,
+				Connector:          ConnectorNoSeparable,
+				ComplianceCodes:    [8]byte{1 << 7},
+				ExtendedCompliance: ExtendedCompliance100GBASECR4,
+				NominalBitRateMbps: 25750,
+				Lengths:            Lengths{CopperMeters: 2},
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseQSFP(tt.b)
+			if tt.ok && err != nil {
+				t.Fatalf("failed to parse QSFP: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+			if err != nil {
+				t.Logf("err: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.q, q); diff != "" {
+				t.Fatalf("unexpected QSFP (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadQSFP(t *testing.T) {
+	b := qsfpSR4()
+	want, err := ParseQSFP(b)
+	if err != nil {
+		t.Fatalf("failed to parse QSFP: %v", err)
+	}
+
+	// Only the lower page, upper page 00h, and upper page 03h may be read.
+	r := &pageReader{
+		b:     b,
+		pages: map[int]bool{0: true, 1: true, 4: true},
+	}
+
+	got, err := ReadQSFP(r)
+	if err != nil {
+		t.Fatalf("failed to read QSFP: %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected QSFP (-want +got):\n%s", diff)
+	}
+
+	// Flat memory modules have no upper page 03h.
+	flat := b[:qsfpLen]
+	flat[2] |= 1 << 2
+
+	q, err := ReadQSFP(bytes.NewReader(flat))
+	if err != nil {
+		t.Fatalf("failed to read flat QSFP: %v", err)
+	}
+	if q.Thresholds != nil {
+		t.Fatalf("expected no thresholds, but got: %+v", q.Thresholds)
+	}
+}
+
+// A pageReader is an io.ReaderAt which only permits reads from the specified
+// 128 byte pages of b.
+type pageReader struct {
+	b     []byte
+	pages map[int]bool
+}
+
+func (r *pageReader) ReadAt(p []byte, off int64) (int, error) {
+	for i := off; i < off+int64(len(p)); i += pageLen {
+		if !r.pages[int(i/pageLen)] {
+			return 0, fmt.Errorf("read of unexpected page at offset %d", i)
+		}
+	}
+
+	return bytes.NewReader(r.b).ReadAt(p, off)
+}
+
+// qsfpSR4 returns the module memory of a typical 100GBASE-SR4 QSFP28 module,
+// through upper page 03h.
+func qsfpSR4() []byte {
+	b := make([]byte, qsfpPage03Len)
+	u16 := func(off int, v uint16) {
+		binary.BigEndian.PutUint16(b[off:], v)
+	}
+
+	// Lower page.
+	b[0] = byte(IdentifierQSFP28)
+	b[1] = 0x07
+	b[3] = 1<<0 | 1<<5
+	b[4] = 1 << 3
+	b[5] = 1<<1 | 1<<6
+	b[6] = 1 << 5
+	b[7] = 1 << 4
+	b[9] = 0x04
+	b[10] = 0x01
+	b[13] = 0x80
+	u16(22, 0x2480)
+	u16(26, 33000)
+	for i := range 4 {
+		u16(34+2*i, uint16(5000+1000*i))
+		u16(42+2*i, uint16(3500+i))
+		u16(50+2*i, uint16(6000+i))
+	}
+
+	// Upper page 00h.
+	b[128] = byte(IdentifierQSFP28)
+	b[129] = 0x8c
+	b[130] = byte(ConnectorMPO1x12)
+	b[131] = 1 << 7
+	b[139] = 0x05
+	b[140] = 0xff
+	b[143], b[144], b[146] = 35, 0, 50
+	b[147] = 0x00
+	copy(b[148:164], "FINISAR CORP    ")
+	copy(b[165:168], []byte{0x00, 0x90, 0x65})
+	copy(b[168:184], "FTLC9551REPM    ")
+	copy(b[184:186], "A0")
+	u16(186, 850*20)
+	b[192] = byte(ExtendedCompliance100GBASESR4)
+	copy(b[196:212], "X3GABCD         ")
+	copy(b[212:220], "19120501")
+	b[220] = 1<<3 | 1<<2
+	b[222] = 103
+	for i := 128; i < 191; i++ {
+		b[191] += b[i]
+	}
+	for i := 192; i < 223; i++ {
+		b[223] += b[i]
+	}
+
+	// Upper page 03h thresholds.
+	p := b[3*pageLen:]
+	for off, vs := range map[int][4]uint16{
+		128: {75 << 8, 0xfb00, 70 << 8, 0},
+		144: {36000, 30000, 35000, 31000},
+		176: {34673, 407, 17378, 813},
+		184: {6500, 1500, 6000, 2000},
+		192: {28183, 724, 14125, 1445},
+	} {
+		for i, v := range vs {
+			binary.BigEndian.PutUint16(p[off+2*i:], v)
+		}
+	}
+
+	return b
+}
+
+// qsfpSR4Want returns the expected QSFP for qsfpSR4, without thresholds.
+func qsfpSR4Want() *QSFP {
+	return &QSFP{
+		Identifier:         IdentifierQSFP28,
+		RevisionCompliance: 0x07,
+		ExtIdentifier:      0x8c,
+		Connector:          ConnectorMPO1x12,
+		ComplianceCodes:    [8]byte{1 << 7},
+		ExtendedCompliance: ExtendedCompliance100GBASESR4,
+		Encoding:           0x05,
+		NominalBitRateMbps: 25750,
+		Lengths:            Lengths{OM3Meters: 70, OM4Meters: 100},
+		Vendor: Vendor{
+			Name:         "FINISAR CORP",
+			OUI:          [3]byte{0x00, 0x90, 0x65},
+			PartNumber:   "FTLC9551REPM",
+			Revision:     "A0",
+			SerialNumber: "X3GABCD",
+			DateCode:     time.Date(2019, time.December, 5, 0, 0, 0, 0, time.UTC),
+			LotCode:      "01",
+		},
+		WavelengthNanometers:  850,
+		RXPowerAverage:        true,
+		BaseChecksumValid:     true,
+		ExtendedChecksumValid: true,
+		TemperatureCelsius:    36.5,
+		VoltageVolts:          3.3,
+		TemperatureFlags:      AlarmFlags{HighWarning: true},
+		VoltageFlags:          AlarmFlags{LowWarning: true},
+		Channels: [4]QSFPChannel{
+			{
+				RXPowerMilliwatts: 0.5,
+				TXBiasMilliamps:   7,
+				TXPowerMilliwatts: 0.6,
+				TXPowerFlags:      AlarmFlags{HighAlarm: true},
+				RXLOS:             true,
+			},
+			{
+				RXPowerMilliwatts: 0.6,
+				TXBiasMilliamps:   7.002,
+				TXPowerMilliwatts: 0.6001,
+				RXPowerFlags:      AlarmFlags{LowAlarm: true},
+				TXLOS:             true,
+				RXCDRLOL:          true,
+			},
+			{
+				RXPowerMilliwatts: 0.7,
+				TXBiasMilliamps:   7.004,
+				TXPowerMilliwatts: 0.6002,
+				TXCDRLOL:          true,
+			},
+			{
+				RXPowerMilliwatts: 0.8,
+				TXBiasMilliamps:   7.006,
+				TXPowerMilliwatts: 0.6003,
+				RXPowerFlags:      AlarmFlags{LowWarning: true},
+				TXFault:           true,
+			},
+		},
+	}
+}
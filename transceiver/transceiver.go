@@ -0,0 +1,413 @@
+// Package transceiver decodes the EEPROM contents of pluggable transceiver
+// modules such as SFP, QSFP, and QSFP-DD modules.
+//
+// The decoders operate on raw bytes and have no dependency on the ethtool
+// netlink interface, so they may be used with data read from a Linux system
+// by an ethtool.Client or with offline dumps of module memory. Read* functions
+// accept an io.ReaderAt which addresses module memory as a flat space, where
+// offsets 0 through 255 address the lower page and upper page 00h and upper
+// page N begins at offset 128*(N+1). An *ethtool.ModuleEEPROMReader satisfies
+// this interface:
+//
+//	r := c.ModuleEEPROMReader(ifi, ethtool.I2CAddressA0, 0)
+//	sfp, err := transceiver.ReadSFP(r)
+package transceiver
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// pageLen is the length of a module EEPROM page.
+const pageLen = 128
+
+// An Identifier is the SFF-8024 physical device identifier of a module, which
+// is stored in the first byte of module memory.
+type Identifier uint8
+
+// Possible Identifier values.
+const (
+	IdentifierUnknown  Identifier = 0x00
+	IdentifierGBIC     Identifier = 0x01
+	IdentifierSoldered Identifier = 0x02
+	IdentifierSFP      Identifier = 0x03
+	IdentifierXFP      Identifier = 0x06
+	IdentifierQSFP     Identifier = 0x0c
+	IdentifierQSFPPlus Identifier = 0x0d
+	IdentifierCXP      Identifier = 0x0e
+	IdentifierQSFP28   Identifier = 0x11
+	IdentifierQSFPDD   Identifier = 0x18
+	IdentifierOSFP     Identifier = 0x19
+	IdentifierSFPDD    Identifier = 0x1a
+	IdentifierDSFP     Identifier = 0x1b
+	IdentifierQSFPCMIS Identifier = 0x1e
+)
+
+// String returns the string representation of an Identifier.
+func (i Identifier) String() string {
+	switch i {
+	case IdentifierUnknown:
+		return "Unknown"
+	case IdentifierGBIC:
+		return "GBIC"
+	case IdentifierSoldered:
+		return "Soldered"
+	case IdentifierSFP:
+		return "SFP"
+	case IdentifierXFP:
+		return "XFP"
+	case IdentifierQSFP:
+		return "QSFP"
+	case IdentifierQSFPPlus:
+		return "QSFP+"
+	case IdentifierCXP:
+		return "CXP"
+	case IdentifierQSFP28:
+		return "QSFP28"
+	case IdentifierQSFPDD:
+		return "QSFP-DD"
+	case IdentifierOSFP:
+		return "OSFP"
+	case IdentifierSFPDD:
+		return "SFP-DD"
+	case IdentifierDSFP:
+		return "DSFP"
+	case IdentifierQSFPCMIS:
+		return "QSFP+ (CMIS)"
+	default:
+		return "Identifier(" + strconv.Itoa(int(i)) + ")"
+	}
+}
+
+// A Connector is the SFF-8024 connector type of a module.
+type Connector uint8
+
+// Possible Connector values.
+const (
+	ConnectorUnknown        Connector = 0x00
+	ConnectorSC             Connector = 0x01
+	ConnectorFCStyle1       Connector = 0x02
+	ConnectorFCStyle2       Connector = 0x03
+	ConnectorBNC            Connector = 0x04
+	ConnectorFCCoax         Connector = 0x05
+	ConnectorFiberJack      Connector = 0x06
+	ConnectorLC             Connector = 0x07
+	ConnectorMTRJ           Connector = 0x08
+	ConnectorMU             Connector = 0x09
+	ConnectorSG             Connector = 0x0a
+	ConnectorOpticalPigtail Connector = 0x0b
+	ConnectorMPO1x12        Connector = 0x0c
+	ConnectorMPO2x16        Connector = 0x0d
+	ConnectorHSSDCII        Connector = 0x20
+	ConnectorCopperPigtail  Connector = 0x21
+	ConnectorRJ45           Connector = 0x22
+	ConnectorNoSeparable    Connector = 0x23
+	ConnectorMXC2x16        Connector = 0x24
+	ConnectorCS             Connector = 0x25
+	ConnectorSN             Connector = 0x26
+	ConnectorMPO2x12        Connector = 0x27
+	ConnectorMPO1x16        Connector = 0x28
+)
+
+// String returns the string representation of a Connector.
+func (c Connector) String() string {
+	switch c {
+	case ConnectorUnknown:
+		return "Unknown"
+	case ConnectorSC:
+		return "SC"
+	case ConnectorFCStyle1:
+		return "FC Style 1"
+	case ConnectorFCStyle2:
+		return "FC Style 2"
+	case ConnectorBNC:
+		return "BNC/TNC"
+	case ConnectorFCCoax:
+		return "FC coax"
+	case ConnectorFiberJack:
+		return "Fiber Jack"
+	case ConnectorLC:
+		return "LC"
+	case ConnectorMTRJ:
+		return "MT-RJ"
+	case ConnectorMU:
+		return "MU"
+	case ConnectorSG:
+		return "SG"
+	case ConnectorOpticalPigtail:
+		return "Optical pigtail"
+	case ConnectorMPO1x12:
+		return "MPO 1x12"
+	case ConnectorMPO2x16:
+		return "MPO 2x16"
+	case ConnectorHSSDCII:
+		return "HSSDC II"
+	case ConnectorCopperPigtail:
+		return "Copper pigtail"
+	case ConnectorRJ45:
+		return "RJ45"
+	case ConnectorNoSeparable:
+		return "No separable connector"
+	case ConnectorMXC2x16:
+		return "MXC 2x16"
+	case ConnectorCS:
+		return "CS"
+	case ConnectorSN:
+		return "SN"
+	case ConnectorMPO2x12:
+		return "MPO 2x12"
+	case ConnectorMPO1x16:
+		return "MPO 1x16"
+	default:
+		return "Connector(" + strconv.Itoa(int(c)) + ")"
+	}
+}
+
+// An ExtendedCompliance is an SFF-8024 extended specification compliance
+// code, which describes interfaces that are not covered by the compliance
+// code bits of SFF-8472 and SFF-8636.
+type ExtendedCompliance uint8
+
+// Possible ExtendedCompliance values.
+const (
+	ExtendedComplianceUnspecified   ExtendedCompliance = 0x00
+	ExtendedCompliance100GAOC       ExtendedCompliance = 0x01
+	ExtendedCompliance100GBASESR4   ExtendedCompliance = 0x02
+	ExtendedCompliance100GBASELR4   ExtendedCompliance = 0x03
+	ExtendedCompliance100GBASEER4   ExtendedCompliance = 0x04
+	ExtendedCompliance100GBASESR10  ExtendedCompliance = 0x05
+	ExtendedCompliance100GCWDM4     ExtendedCompliance = 0x06
+	ExtendedCompliance100GPSM4      ExtendedCompliance = 0x07
+	ExtendedCompliance100GACC       ExtendedCompliance = 0x08
+	ExtendedCompliance100GBASECR4   ExtendedCompliance = 0x0b
+	ExtendedCompliance25GBASECRS    ExtendedCompliance = 0x0c
+	ExtendedCompliance25GBASECRN    ExtendedCompliance = 0x0d
+	ExtendedCompliance40GBASEER4    ExtendedCompliance = 0x10
+	ExtendedCompliance10GBASETSFI   ExtendedCompliance = 0x16
+	ExtendedCompliance100GCLR4      ExtendedCompliance = 0x17
+	ExtendedCompliance100GAOCLowBER ExtendedCompliance = 0x18
+	ExtendedCompliance100GACCLowBER ExtendedCompliance = 0x19
+	ExtendedCompliance10GBASETShort ExtendedCompliance = 0x1c
+	ExtendedCompliance5GBASET       ExtendedCompliance = 0x1d
+	ExtendedCompliance2Point5GBASET ExtendedCompliance = 0x1e
+	ExtendedCompliance100GBASEDR    ExtendedCompliance = 0x25
+	ExtendedCompliance100GFR        ExtendedCompliance = 0x26
+	ExtendedCompliance100GLR        ExtendedCompliance = 0x27
+)
+
+// String returns the string representation of an ExtendedCompliance.
+func (e ExtendedCompliance) String() string {
+	switch e {
+	case ExtendedComplianceUnspecified:
+		return "Unspecified"
+	case ExtendedCompliance100GAOC:
+		return "100G AOC or 25GAUI C2M AOC"
+	case ExtendedCompliance100GBASESR4:
+		return "100GBASE-SR4 or 25GBASE-SR"
+	case ExtendedCompliance100GBASELR4:
+		return "100GBASE-LR4 or 25GBASE-LR"
+	case ExtendedCompliance100GBASEER4:
+		return "100GBASE-ER4 or 25GBASE-ER"
+	case ExtendedCompliance100GBASESR10:
+		return "100GBASE-SR10"
+	case ExtendedCompliance100GCWDM4:
+		return "100G CWDM4"
+	case ExtendedCompliance100GPSM4:
+		return "100G PSM4"
+	case ExtendedCompliance100GACC:
+		return "100G ACC or 25GAUI C2M ACC"
+	case ExtendedCompliance100GBASECR4:
+		return "100GBASE-CR4 or 25GBASE-CR CA-L"
+	case ExtendedCompliance25GBASECRS:
+		return "25GBASE-CR CA-S"
+	case ExtendedCompliance25GBASECRN:
+		return "25GBASE-CR CA-N"
+	case ExtendedCompliance40GBASEER4:
+		return "40GBASE-ER4"
+	case ExtendedCompliance10GBASETSFI:
+		return "10GBASE-T with SFI"
+	case ExtendedCompliance100GCLR4:
+		return "100G CLR4"
+	case ExtendedCompliance100GAOCLowBER:
+		return "100G AOC or 25GAUI C2M AOC (BER 1e-12)"
+	case ExtendedCompliance100GACCLowBER:
+		return "100G ACC or 25GAUI C2M ACC (BER 1e-12)"
+	case ExtendedCompliance10GBASETShort:
+		return "10GBASE-T Short Reach"
+	case ExtendedCompliance5GBASET:
+		return "5GBASE-T"
+	case ExtendedCompliance2Point5GBASET:
+		return "2.5GBASE-T"
+	case ExtendedCompliance100GBASEDR:
+		return "100GBASE-DR"
+	case ExtendedCompliance100GFR:
+		return "100G-FR or 100GBASE-FR1"
+	case ExtendedCompliance100GLR:
+		return "100G-LR or 100GBASE-LR1"
+	default:
+		return "ExtendedCompliance(" + strconv.Itoa(int(e)) + ")"
+	}
+}
+
+// Vendor contains the vendor identification fields of a module.
+type Vendor struct {
+	Name         string
+	OUI          [3]byte
+	PartNumber   string
+	Revision     string
+	SerialNumber string
+
+	// DateCode is the manufacturing date of the module, or the zero value
+	// if the date code is not valid. LotCode is the optional vendor specific
+	// lot code which follows the date.
+	DateCode time.Time
+	LotCode  string
+}
+
+// Lengths contains the supported link lengths of a module for each medium,
+// in meters. A length of 0 indicates the medium is not supported or the
+// length was not specified.
+type Lengths struct {
+	SMFMeters    int
+	OM1Meters    int
+	OM2Meters    int
+	OM3Meters    int
+	OM4Meters    int
+	CopperMeters int
+}
+
+// AlarmFlags contains the latched alarm and warning flags of a monitored
+// value.
+type AlarmFlags struct {
+	HighAlarm   bool
+	LowAlarm    bool
+	HighWarning bool
+	LowWarning  bool
+}
+
+// Thresholds contains the alarm and warning thresholds of a monitored value,
+// in the same units as the value.
+type Thresholds struct {
+	HighAlarm   float64
+	LowAlarm    float64
+	HighWarning float64
+	LowWarning  float64
+}
+
+// alarmFlags decodes AlarmFlags from the low 4 bits of b, which are ordered
+// high alarm, low alarm, high warning, and low warning from the most
+// significant bit.
+func alarmFlags(b byte) AlarmFlags {
+	return AlarmFlags{
+		HighAlarm:   b&(1<<3) != 0,
+		LowAlarm:    b&(1<<2) != 0,
+		HighWarning: b&(1<<1) != 0,
+		LowWarning:  b&(1<<0) != 0,
+	}
+}
+
+// thresholds decodes Thresholds from 4 consecutive 16-bit values in b using
+// fn, which are ordered high alarm, low alarm, high warning, and low warning.
+func thresholds(b []byte, fn func([]byte) float64) Thresholds {
+	return Thresholds{
+		HighAlarm:   fn(b[0:2]),
+		LowAlarm:    fn(b[2:4]),
+		HighWarning: fn(b[4:6]),
+		LowWarning:  fn(b[6:8]),
+	}
+}
+
+// Decoders for the monitored values common to SFF-8472 (when internally
+// calibrated), SFF-8636, and CMIS.
+
+// temperature decodes a signed temperature in units of 1/256 degrees Celsius.
+func temperature(b []byte) float64 {
+	return float64(int16(binary.BigEndian.Uint16(b))) / 256
+}
+
+// voltage decodes a voltage in units of 100 microvolts as volts.
+func voltage(b []byte) float64 {
+	return float64(binary.BigEndian.Uint16(b)) / 10000
+}
+
+// bias decodes a laser bias current in units of 2 microamps as milliamps.
+func bias(b []byte) float64 {
+	return float64(binary.BigEndian.Uint16(b)) * 2 / 1000
+}
+
+// power decodes an optical power in units of 0.1 microwatts as milliwatts.
+func power(b []byte) float64 {
+	return float64(binary.BigEndian.Uint16(b)) / 10000
+}
+
+// A complianceName maps a bit in a compliance code byte to a name.
+type complianceName struct {
+	byte, bit int
+	name      string
+}
+
+// compliance returns the names of the compliance codes set in codes.
+func compliance(codes []byte, names []complianceName) []string {
+	var out []string
+	for _, c := range names {
+		if codes[c.byte]&(1<<c.bit) != 0 {
+			out = append(out, c.name)
+		}
+	}
+
+	return out
+}
+
+// readAll reads len(b) bytes from r at offset off.
+func readAll(r io.ReaderAt, b []byte, off int64) error {
+	n, err := r.ReadAt(b, off)
+	if n == len(b) {
+		// io.ReaderAt may return io.EOF alongside a full read at the end of
+		// the input.
+		return nil
+	}
+	if err == nil || err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return err
+}
+
+// checkLen verifies that b contains at least n bytes for the named page.
+func checkLen(b []byte, n int, page string) error {
+	if len(b) < n {
+		return fmt.Errorf("transceiver: %s must be at least %d bytes, got %d", page, n, len(b))
+	}
+
+	return nil
+}
+
+// str decodes a space padded ASCII string field.
+func str(b []byte) string {
+	return string(bytes.TrimRight(b, " \x00"))
+}
+
+// dateCode decodes an 8 byte date code field, consisting of a YYMMDD date
+// followed by an optional 2 byte lot code.
+func dateCode(b []byte) (time.Time, string) {
+	date, err := time.Parse("060102", string(b[:6]))
+	if err != nil {
+		date = time.Time{}
+	}
+
+	return date, str(b[6:8])
+}
+
+// checksum reports whether the low 8 bits of the sum of b equal want.
+func checksum(b []byte, want byte) bool {
+	var sum byte
+	for _, v := range b {
+		sum += v
+	}
+
+	return sum == want
+}